@@ -0,0 +1,128 @@
+package strife
+
+import (
+	"time"
+)
+
+// AnimationMode is what an animation does when
+// it gets to its last frame.
+type AnimationMode int
+
+// Types of animation modes, Loop goes back to the first
+// frame; PingPong plays the frames backwards and then
+// forwards again; and Once stops on the last frame.
+const (
+	Loop AnimationMode = iota
+	PingPong
+	Once
+)
+
+// Animation plays a sequence of frames from a sprite sheet, each
+// frame is shown for its duration in the sheet. Animations are
+// moved along by calling Update every frame.
+type Animation struct {
+	Sheet  *SpriteSheet
+	Frames []int
+	Mode   AnimationMode
+
+	// Speed scales how fast the animation plays,
+	// 1 is normal speed.
+	Speed float64
+
+	position  int
+	direction int
+	elapsed   time.Duration
+	finished  bool
+}
+
+// NewAnimation creates an animation of the given frames of
+// the sprite sheet. If no frames are given then every frame
+// in the sheet is played in order.
+func NewAnimation(sheet *SpriteSheet, mode AnimationMode, frames ...int) *Animation {
+	if len(frames) == 0 {
+		frames = make([]int, len(sheet.Frames))
+		for i := range frames {
+			frames[i] = i
+		}
+	}
+	return &Animation{
+		Sheet:     sheet,
+		Frames:    frames,
+		Mode:      mode,
+		Speed:     1,
+		direction: 1,
+	}
+}
+
+// Update moves the animation along by the given
+// amount of time.
+func (a *Animation) Update(delta time.Duration) {
+	if a.finished || len(a.Frames) == 0 {
+		return
+	}
+
+	a.elapsed += time.Duration(float64(delta) * a.Speed)
+	for !a.finished {
+		duration := a.Sheet.Frames[a.Frame()].Duration
+		if duration <= 0 {
+			duration = DefaultFrameDuration
+		}
+		if a.elapsed < duration {
+			break
+		}
+		a.elapsed -= duration
+		a.advance()
+	}
+}
+
+// advance moves on to the next frame
+func (a *Animation) advance() {
+	last := len(a.Frames) - 1
+	switch a.Mode {
+	case Once:
+		if a.position == last {
+			a.finished = true
+			a.elapsed = 0
+			return
+		}
+		a.position++
+	case PingPong:
+		if last == 0 {
+			return
+		}
+		next := a.position + a.direction
+		if next < 0 || next > last {
+			a.direction = -a.direction
+			next = a.position + a.direction
+		}
+		a.position = next
+	default:
+		a.position = (a.position + 1) % len(a.Frames)
+	}
+}
+
+// Frame returns the index in the sprite sheet of
+// the frame that is currently showing.
+func (a *Animation) Frame() int {
+	return a.Frames[a.position]
+}
+
+// Finished checks if an animation that is played
+// Once has reached the end of its last frame.
+func (a *Animation) Finished() bool {
+	return a.finished
+}
+
+// Reset starts the animation again from its first frame
+func (a *Animation) Reset() {
+	a.position = 0
+	a.direction = 1
+	a.elapsed = 0
+	a.finished = false
+}
+
+// Animation will render the current frame of the given
+// animation at the given x, y co-ordinates.
+func (r *Renderer) Animation(animation *Animation, x, y int) {
+	r.Frame(animation.Sheet, animation.Frame(), x, y)
+}
@@ -0,0 +1,278 @@
+package strife
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// DefaultPollInterval is how often the asset
+// files are checked for changes when hot
+// reloading is on.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Assets caches images and fonts so that each file is only loaded
+// once however many times it is asked for. Each time an asset is
+// asked for a new handle to it is returned, and the asset is destroyed
+// when every handle to it has been released.
+//
+// When HotReload is on, Update checks the files for changes and
+// reloads any that were changed. A reloaded asset is swapped in place
+// so everything holding a handle draws the new version.
+//
+// The zero value is ready to use, it loads from the OS and checks
+// for changes on every Update. Assets must only be used from the
+// thread that renders.
+type Assets struct {
+	// FS is the file system assets are loaded from,
+	// if it is nil they are loaded from the OS.
+	FS fs.FS
+
+	HotReload    bool
+	PollInterval time.Duration
+
+	images   map[string]*imageAsset
+	fonts    map[fontKey]*fontAsset
+	lastPoll time.Time
+}
+
+type imageAsset struct {
+	image     *Image
+	path      string
+	refs      int
+	modTime   time.Time
+	destroyed bool
+}
+
+type fontKey struct {
+	path string
+	size int
+}
+
+type fontAsset struct {
+	font      *Font
+	key       fontKey
+	refs      int
+	modTime   time.Time
+	destroyed bool
+}
+
+// ImageHandle is a shared image from Assets. The image
+// must not be destroyed, call Release when finished with it.
+type ImageHandle struct {
+	*Image
+	assets   *Assets
+	asset    *imageAsset
+	released bool
+}
+
+// FontHandle is a shared font from Assets. The font
+// must not be destroyed, call Release when finished with it.
+type FontHandle struct {
+	*Font
+	assets   *Assets
+	asset    *fontAsset
+	released bool
+}
+
+// NewAssets creates an empty asset cache that loads from
+// the OS, and checks for changes every DefaultPollInterval.
+func NewAssets() *Assets {
+	return &Assets{
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// Image returns a handle to the image at the given path,
+// loading it if it isn't loaded already.
+func (a *Assets) Image(path string) (*ImageHandle, error) {
+	if a.images == nil {
+		a.images = map[string]*imageAsset{}
+	}
+	asset, ok := a.images[path]
+	if !ok {
+		modTime, _ := a.modTime(path)
+		image, err := a.loadImage(path)
+		if err != nil {
+			return nil, err
+		}
+		asset = &imageAsset{image: image, path: path, modTime: modTime}
+		a.images[path] = asset
+	}
+	asset.refs++
+	return &ImageHandle{Image: asset.image, assets: a, asset: asset}, nil
+}
+
+// Font returns a handle to the font at the given path of
+// the given size, loading it if it isn't loaded already.
+func (a *Assets) Font(path string, size int) (*FontHandle, error) {
+	if a.fonts == nil {
+		a.fonts = map[fontKey]*fontAsset{}
+	}
+	key := fontKey{path, size}
+	asset, ok := a.fonts[key]
+	if !ok {
+		modTime, _ := a.modTime(path)
+		font, err := a.loadFont(path, size)
+		if err != nil {
+			return nil, err
+		}
+		asset = &fontAsset{font: font, key: key, modTime: modTime}
+		a.fonts[key] = asset
+	}
+	asset.refs++
+	return &FontHandle{Font: asset.font, assets: a, asset: asset}, nil
+}
+
+// Release gives up the handle, the image is destroyed
+// once all of the handles to it have been released.
+func (h *ImageHandle) Release() {
+	if h.released {
+		return
+	}
+	h.released = true
+	h.asset.refs--
+	if h.asset.refs > 0 || h.asset.destroyed {
+		return
+	}
+	h.asset.image.Destroy()
+	h.asset.destroyed = true
+	if h.assets.images[h.asset.path] == h.asset {
+		delete(h.assets.images, h.asset.path)
+	}
+}
+
+// Release gives up the handle, the font is destroyed
+// once all of the handles to it have been released.
+func (h *FontHandle) Release() {
+	if h.released {
+		return
+	}
+	h.released = true
+	h.asset.refs--
+	if h.asset.refs > 0 || h.asset.destroyed {
+		return
+	}
+	h.asset.font.Destroy()
+	h.asset.destroyed = true
+	if h.assets.fonts[h.asset.key] == h.asset {
+		delete(h.assets.fonts, h.asset.key)
+	}
+}
+
+// Update checks for changed files and reloads them if HotReload
+// is on, it should be called once a frame. The files are only
+// checked every PollInterval. If an asset fails to reload the old
+// version is kept and the first error is returned.
+func (a *Assets) Update() error {
+	if !a.HotReload || time.Since(a.lastPoll) < a.PollInterval {
+		return nil
+	}
+	a.lastPoll = time.Now()
+
+	var firstErr error
+	for _, asset := range a.images {
+		modTime, err := a.modTime(asset.path)
+		if err != nil || modTime.Equal(asset.modTime) {
+			continue
+		}
+		asset.modTime = modTime
+		if err := a.reloadImage(asset); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, asset := range a.fonts {
+		modTime, err := a.modTime(asset.key.path)
+		if err != nil || modTime.Equal(asset.modTime) {
+			continue
+		}
+		asset.modTime = modTime
+		if err := a.reloadFont(asset); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Destroy destroys every asset, even if there are handles to
+// them that haven't been released. Releasing those handles
+// afterwards does nothing.
+func (a *Assets) Destroy() {
+	for path, asset := range a.images {
+		asset.image.Destroy()
+		asset.destroyed = true
+		delete(a.images, path)
+	}
+	for key, asset := range a.fonts {
+		asset.font.Destroy()
+		asset.destroyed = true
+		delete(a.fonts, key)
+	}
+}
+
+// reloadImage loads the image again and swaps the new
+// texture and surface into the image that is shared.
+func (a *Assets) reloadImage(asset *imageAsset) error {
+	fresh, err := a.loadImage(asset.path)
+	if err != nil {
+		return fmt.Errorf("Failed to reload image '%s': %s", asset.path, err)
+	}
+
+	image := asset.image
+	image.Texture.Destroy()
+	if image.Surface != nil {
+		image.Surface.Free()
+	}
+	image.Texture = fresh.Texture
+	image.Surface = fresh.Surface
+	image.Width, image.Height = fresh.Width, fresh.Height
+	return nil
+}
+
+// reloadFont loads the font again and swaps it into
+// the font that is shared, clearing its glyph cache.
+func (a *Assets) reloadFont(asset *fontAsset) error {
+	fresh, err := a.loadFont(asset.key.path, asset.key.size)
+	if err != nil {
+		return fmt.Errorf("Failed to reload font '%s': %s", asset.key.path, err)
+	}
+
+	font := asset.font
+	for _, glyph := range font.texCache {
+		glyph.tex.Destroy()
+	}
+	font.close()
+	font.Font = fresh.Font
+	font.mem = fresh.mem
+	font.texCache = fresh.texCache
+	return nil
+}
+
+func (a *Assets) loadImage(path string) (*Image, error) {
+	if a.FS != nil {
+		return LoadImageFS(a.FS, path)
+	}
+	return LoadImage(path)
+}
+
+func (a *Assets) loadFont(path string, size int) (*Font, error) {
+	if a.FS != nil {
+		return LoadFontFS(a.FS, path, size)
+	}
+	return LoadFont(path, size)
+}
+
+func (a *Assets) modTime(path string) (time.Time, error) {
+	var info fs.FileInfo
+	var err error
+	if a.FS != nil {
+		info, err = fs.Stat(a.FS, path)
+	} else {
+		info, err = os.Stat(path)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,336 @@
+package strife
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Sprite is a handle to an image that has been packed into
+// an atlas. It is the area X, Y, W, H of the atlas page it is on,
+// and is drawn with Renderer.Sprite or through SubImage.
+type Sprite struct {
+	Page       *Image
+	X, Y, W, H int
+}
+
+// Sprite will render the given sprite at the given
+// x, y co-ordinates at its full size.
+func (r *Renderer) Sprite(sprite *Sprite, x, y int) {
+	r.SubImage(sprite.Page, x, y, sprite.X, sprite.Y, sprite.W, sprite.H)
+}
+
+// SpriteScale will render the given sprite at the given
+// co-ordinate scaled to the given size.
+func (r *Renderer) SpriteScale(sprite *Sprite, x, y, w, h int) {
+	r.SubImageScale(sprite.Page, x, y, sprite.X, sprite.Y, sprite.W, sprite.H, w, h)
+}
+
+type atlasEntry struct {
+	name    string
+	surface *sdl.Surface
+}
+
+// Atlas packs lots of small images into a few large textures
+// so that they can be drawn without switching textures. Images
+// are added with AddImage or AddSurface and are packed into
+// pages when Build is called.
+// PageWidth, PageHeight => The size of each texture; and
+// Padding => The gap left around each image so that
+// they don't bleed into each other when scaled.
+type Atlas struct {
+	PageWidth, PageHeight int
+	Padding               int
+
+	entries []atlasEntry
+	sprites map[string]*Sprite
+	pages   []*Image
+	used    []int
+}
+
+// NewAtlas creates an empty atlas with pages of the given
+// size, and a padding of one pixel.
+func NewAtlas(pageWidth, pageHeight int) *Atlas {
+	return &Atlas{
+		PageWidth:  pageWidth,
+		PageHeight: pageHeight,
+		Padding:    1,
+		sprites:    map[string]*Sprite{},
+	}
+}
+
+// AddImage adds the given image to the atlas under the given
+// name. The pixels are copied so the image can be destroyed
+// once it has been added.
+func (a *Atlas) AddImage(name string, image *Image) error {
+	if image.Surface == nil {
+		return fmt.Errorf("Image '%s' has no surface to pack", name)
+	}
+	return a.AddSurface(name, image.Surface)
+}
+
+// AddSurface adds the given surface to the atlas under the given
+// name. The pixels are copied so the surface can be freed once
+// it has been added.
+func (a *Atlas) AddSurface(name string, surface *sdl.Surface) error {
+	if _, exists := a.sprites[name]; exists {
+		return fmt.Errorf("Atlas already has an image called '%s'", name)
+	}
+	w, h := int(surface.W)+a.Padding*2, int(surface.H)+a.Padding*2
+	if w > a.PageWidth || h > a.PageHeight {
+		return fmt.Errorf("Image '%s' is too big for the atlas pages", name)
+	}
+
+	// the atlas keeps its own copy as every
+	// entry is packed again on each Build.
+	copied, err := surface.ConvertFormat(uint32(sdl.PIXELFORMAT_RGBA32), 0)
+	if err != nil {
+		return fmt.Errorf("Failed to copy image '%s': %s", name, err)
+	}
+
+	a.entries = append(a.entries, atlasEntry{name, copied})
+	a.sprites[name] = &Sprite{}
+	return nil
+}
+
+// Build packs all of the added images into as few pages as it
+// can and creates their textures. Sprites that were handed out
+// before are updated in place, so Build can be called again
+// after more images are added.
+func (a *Atlas) Build() error {
+	if RenderInstance == nil {
+		return fmt.Errorf("Render context has not been initialized yet.")
+	}
+
+	// packing the biggest images first wastes the least space.
+	order := make([]int, len(a.entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		si, sj := a.entries[order[i]].surface, a.entries[order[j]].surface
+		if si.H != sj.H {
+			return si.H > sj.H
+		}
+		return si.W > sj.W
+	})
+
+	type placement struct {
+		page, x, y int
+	}
+	placements := make([]placement, len(a.entries))
+	var packers []*skyline
+	used := []int{}
+
+	for _, i := range order {
+		s := a.entries[i].surface
+		w, h := int(s.W)+a.Padding*2, int(s.H)+a.Padding*2
+
+		placed := false
+		for p, packer := range packers {
+			if x, y, ok := packer.insert(w, h); ok {
+				placements[i] = placement{p, x, y}
+				used[p] += w * h
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			packer := newSkyline(a.PageWidth, a.PageHeight)
+			x, y, _ := packer.insert(w, h)
+			packers = append(packers, packer)
+			used = append(used, w*h)
+			placements[i] = placement{len(packers) - 1, x, y}
+		}
+	}
+
+	// copy all of the images into the surface
+	// for each page.
+	surfaces := make([]*sdl.Surface, len(packers))
+	for p := range surfaces {
+		surface, err := sdl.CreateRGBSurfaceWithFormat(0, int32(a.PageWidth), int32(a.PageHeight), 32, uint32(sdl.PIXELFORMAT_RGBA32))
+		if err != nil {
+			for _, s := range surfaces[:p] {
+				s.Free()
+			}
+			return fmt.Errorf("Failed to create atlas page: %s", err)
+		}
+		surfaces[p] = surface
+	}
+
+	for i, entry := range a.entries {
+		place := placements[i]
+		src := entry.surface
+
+		// copy the pixels as they are, rather than
+		// blending them onto the empty page.
+		prevMode, _ := src.GetBlendMode()
+		src.SetBlendMode(sdl.BLENDMODE_NONE)
+		src.Blit(nil, surfaces[place.page], &sdl.Rect{
+			int32(place.x + a.Padding), int32(place.y + a.Padding), src.W, src.H,
+		})
+		src.SetBlendMode(prevMode)
+	}
+
+	pages := make([]*Image, len(surfaces))
+	for p, surface := range surfaces {
+		texture, err := RenderInstance.CreateTextureFromSurface(surface)
+		if err != nil {
+			for _, page := range pages[:p] {
+				page.Destroy()
+			}
+			for _, s := range surfaces[p:] {
+				s.Free()
+			}
+			return fmt.Errorf("Failed to load atlas page into memory")
+		}
+		RenderInstance.textureAllocated()
+		pages[p] = newImage(texture, surface, a.PageWidth, a.PageHeight)
+	}
+
+	a.destroyPages()
+	a.pages = pages
+	a.used = used
+
+	for i, entry := range a.entries {
+		place := placements[i]
+		*a.sprites[entry.name] = Sprite{
+			Page: pages[place.page],
+			X:    place.x + a.Padding,
+			Y:    place.y + a.Padding,
+			W:    int(entry.surface.W),
+			H:    int(entry.surface.H),
+		}
+	}
+
+	return nil
+}
+
+// Sprite returns the sprite for the image with the given name. The
+// sprite can't be drawn until the atlas has been built.
+func (a *Atlas) Sprite(name string) (*Sprite, bool) {
+	sprite, ok := a.sprites[name]
+	return sprite, ok
+}
+
+// Pages returns the textures that the images were packed into.
+func (a *Atlas) Pages() []*Image {
+	return a.pages
+}
+
+// Occupancy returns how full each page is, from 0 for
+// empty to 1 for full. Padding counts as used space.
+func (a *Atlas) Occupancy() []float64 {
+	result := make([]float64, len(a.used))
+	for i, used := range a.used {
+		result[i] = float64(used) / float64(a.PageWidth*a.PageHeight)
+	}
+	return result
+}
+
+func (a *Atlas) destroyPages() {
+	for _, page := range a.pages {
+		page.Destroy()
+	}
+	a.pages = nil
+	a.used = nil
+}
+
+// Destroy must be invoked when finished with the atlas, all of
+// its sprites become invalid.
+func (a *Atlas) Destroy() {
+	a.destroyPages()
+	for _, entry := range a.entries {
+		entry.surface.Free()
+	}
+	a.entries = nil
+	a.sprites = map[string]*Sprite{}
+}
+
+// skyline packs rectangles into a page by keeping track of the
+// top edge of everything packed so far, and placing each new
+// rectangle as low down as it will go.
+type skyline struct {
+	w, h  int
+	edges []skylineEdge
+}
+
+// skylineEdge is a flat part of the skyline
+type skylineEdge struct {
+	x, y, w int
+}
+
+func newSkyline(w, h int) *skyline {
+	return &skyline{w, h, []skylineEdge{{0, 0, w}}}
+}
+
+// fit checks if a rectangle of the given size fits at the start
+// of the edge at index i, returning the y it would sit at.
+func (s *skyline) fit(i, w, h int) (int, bool) {
+	x := s.edges[i].x
+	if x+w > s.w {
+		return 0, false
+	}
+
+	y := 0
+	for remaining := w; remaining > 0; i++ {
+		if s.edges[i].y > y {
+			y = s.edges[i].y
+		}
+		remaining -= s.edges[i].w
+	}
+	if y+h > s.h {
+		return 0, false
+	}
+	return y, true
+}
+
+// insert finds a place for a rectangle of the given size,
+// it returns false if there is no room left.
+func (s *skyline) insert(w, h int) (int, int, bool) {
+	best, bestX, bestY, bestW := -1, 0, 0, 0
+	for i, e := range s.edges {
+		y, ok := s.fit(i, w, h)
+		if !ok {
+			continue
+		}
+		if best == -1 || y+h < bestY+h || (y == bestY && e.w < bestW) {
+			best, bestX, bestY, bestW = i, e.x, y, e.w
+		}
+	}
+	if best == -1 {
+		return 0, 0, false
+	}
+
+	// raise the skyline where the rectangle was placed, and cut
+	// back any edges that are now underneath it.
+	placed := skylineEdge{bestX, bestY + h, w}
+	edges := append([]skylineEdge{}, s.edges[:best]...)
+	edges = append(edges, placed)
+	for _, e := range s.edges[best:] {
+		end := e.x + e.w
+		if end <= placed.x+placed.w {
+			continue
+		}
+		if e.x < placed.x+placed.w {
+			e.w = end - (placed.x + placed.w)
+			e.x = placed.x + placed.w
+		}
+		edges = append(edges, e)
+	}
+
+	// join up neighbouring edges at the same height.
+	merged := edges[:1]
+	for _, e := range edges[1:] {
+		last := &merged[len(merged)-1]
+		if last.y == e.y {
+			last.w += e.w
+			continue
+		}
+		merged = append(merged, e)
+	}
+	s.edges = merged
+
+	return bestX, bestY, true
+}
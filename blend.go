@@ -0,0 +1,216 @@
+package strife
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// BlendMode is how colours that are drawn are
+// mixed with what has already been drawn.
+type BlendMode int
+
+// Types of blend modes, BlendAlpha is the default and mixes colours
+// by their alpha; BlendNone overwrites what is underneath; BlendAdd
+// adds the colours together which is good for glows and particles;
+// BlendModulate multiplies what is underneath by the colour, ignoring
+// alpha; and BlendMultiply multiplies what is underneath by the colour,
+// taking alpha into account. Not every renderer can do BlendMultiply,
+// in which case BlendModulate is used instead.
+const (
+	BlendAlpha BlendMode = iota
+	BlendNone
+	BlendAdd
+	BlendModulate
+	BlendMultiply
+)
+
+var blendMultiply = sdl.ComposeCustomBlendMode(
+	sdl.BLENDFACTOR_DST_COLOR, sdl.BLENDFACTOR_ONE_MINUS_SRC_ALPHA, sdl.BLENDOPERATION_ADD,
+	sdl.BLENDFACTOR_ZERO, sdl.BLENDFACTOR_ONE, sdl.BLENDOPERATION_ADD,
+)
+
+// the blend modes for drawing images whose colours are already
+// multiplied by their alpha, so the alpha isn't applied twice.
+var (
+	blendPremultiplied = sdl.ComposeCustomBlendMode(
+		sdl.BLENDFACTOR_ONE, sdl.BLENDFACTOR_ONE_MINUS_SRC_ALPHA, sdl.BLENDOPERATION_ADD,
+		sdl.BLENDFACTOR_ONE, sdl.BLENDFACTOR_ONE_MINUS_SRC_ALPHA, sdl.BLENDOPERATION_ADD,
+	)
+	blendPremultipliedAdd = sdl.ComposeCustomBlendMode(
+		sdl.BLENDFACTOR_ONE, sdl.BLENDFACTOR_ONE, sdl.BLENDOPERATION_ADD,
+		sdl.BLENDFACTOR_ZERO, sdl.BLENDFACTOR_ONE, sdl.BLENDOPERATION_ADD,
+	)
+)
+
+// toSDL returns the SDL blend mode for this blend mode
+func (b BlendMode) toSDL() sdl.BlendMode {
+	switch b {
+	case BlendNone:
+		return sdl.BLENDMODE_NONE
+	case BlendAdd:
+		return sdl.BLENDMODE_ADD
+	case BlendModulate:
+		return sdl.BLENDMODE_MOD
+	case BlendMultiply:
+		return blendMultiply
+	}
+	return sdl.BLENDMODE_BLEND
+}
+
+// modulate multiplies two colour channels together
+func modulate(a, b uint8) uint8 {
+	return uint8((uint16(a)*uint16(b) + 127) / 255)
+}
+
+// tintColor multiplies the colour c by the given tint and
+// alpha, the tint can be nil.
+func tintColor(c sdl.Color, tint *Color, alpha uint8) sdl.Color {
+	if tint != nil {
+		c.R = modulate(c.R, tint.R)
+		c.G = modulate(c.G, tint.G)
+		c.B = modulate(c.B, tint.B)
+		c.A = modulate(c.A, tint.A)
+	}
+	c.A = modulate(c.A, alpha)
+	return c
+}
+
+// SetBlendMode sets how everything drawn afterwards is mixed with
+// what is already there. Images follow the blend mode of the renderer
+// unless they have their own set with Image.SetBlendMode.
+func (r *Renderer) SetBlendMode(mode BlendMode) {
+	r.blend = mode
+	if err := r.SetDrawBlendMode(mode.toSDL()); err != nil && mode == BlendMultiply {
+		r.SetDrawBlendMode(sdl.BLENDMODE_MOD)
+	}
+}
+
+// GetBlendMode returns the current blend mode
+func (r *Renderer) GetBlendMode() BlendMode {
+	return r.blend
+}
+
+// SetTint sets a colour that everything drawn afterwards is
+// multiplied by, including images and text. Use nil for no tint.
+func (r *Renderer) SetTint(tint *Color) {
+	r.tint = tint
+	r.applyDrawColor()
+}
+
+// GetTint returns the current tint, or nil if there is none.
+func (r *Renderer) GetTint() *Color {
+	return r.tint
+}
+
+// SetAlpha sets the opacity of everything that is drawn afterwards,
+// from 0 for invisible to 255 for fully opaque. This is multiplied
+// with the alpha of the colours and images that are drawn.
+func (r *Renderer) SetAlpha(alpha uint8) {
+	r.alpha = alpha
+	r.applyDrawColor()
+}
+
+// GetAlpha returns the current alpha
+func (r *Renderer) GetAlpha() uint8 {
+	return r.alpha
+}
+
+// applyDrawColor passes the current colour with the tint and
+// alpha applied through to SDL.
+func (r *Renderer) applyDrawColor() {
+	c := r.modulate(r.color.ToSDLColor())
+	r.SetDrawColor(c.R, c.G, c.B, c.A)
+}
+
+// modulate applies the renderers tint and alpha to the given colour
+func (r *Renderer) modulate(c sdl.Color) sdl.Color {
+	return tintColor(c, r.tint, r.alpha)
+}
+
+// prepareTexture sets up the blend mode and colour modulation of
+// the given texture before it is drawn. The tint and alpha of the
+// renderer are applied on top of the given modulation.
+func (r *Renderer) prepareTexture(texture *sdl.Texture, mode BlendMode, mod sdl.Color) {
+	setTextureBlend(texture, mode)
+	mod = r.modulate(mod)
+	texture.SetColorMod(mod.R, mod.G, mod.B)
+	texture.SetAlphaMod(mod.A)
+}
+
+// preparePremultiplied is prepareTexture for textures whose colours
+// are already multiplied by their alpha. The alpha and blending
+// are then applied to the colours only once.
+func (r *Renderer) preparePremultiplied(texture *sdl.Texture, mode BlendMode, mod sdl.Color) {
+	mod = r.modulate(mod)
+	switch mode {
+	case BlendAlpha:
+		texture.SetBlendMode(blendPremultiplied)
+	case BlendAdd:
+		texture.SetBlendMode(blendPremultipliedAdd)
+	default:
+		setTextureBlend(texture, mode)
+	}
+	if mode == BlendAlpha || mode == BlendAdd {
+		mod.R, mod.G, mod.B = modulate(mod.R, mod.A), modulate(mod.G, mod.A), modulate(mod.B, mod.A)
+	}
+	texture.SetColorMod(mod.R, mod.G, mod.B)
+	texture.SetAlphaMod(mod.A)
+}
+
+func setTextureBlend(texture *sdl.Texture, mode BlendMode) {
+	if err := texture.SetBlendMode(mode.toSDL()); err != nil && mode == BlendMultiply {
+		texture.SetBlendMode(sdl.BLENDMODE_MOD)
+	}
+}
+
+// drawImage draws the given image with the transform m, see
+// copyTransformed. The tint is applied on top of the images own
+// tint and can be nil.
+func (r *Renderer) drawImage(image *Image, src *sdl.Rect, m Matrix, w, h float64, tint *Color) {
+	mod := tintColor(White.ToSDLColor(), image.tint, image.GetAlpha())
+	mod = tintColor(mod, tint, 255)
+	if image.premultiplied {
+		r.preparePremultiplied(image.Texture, image.blendMode(r), mod)
+	} else {
+		r.prepareTexture(image.Texture, image.blendMode(r), mod)
+	}
+	r.copyTransformed(image.Texture, src, m, w, h)
+}
+
+// SetBlendMode sets how this image is mixed with what is already
+// drawn, overriding the blend mode of the renderer.
+func (i *Image) SetBlendMode(mode BlendMode) {
+	i.blend = mode
+	i.hasBlend = true
+}
+
+// ClearBlendMode makes this image follow the blend
+// mode of the renderer again.
+func (i *Image) ClearBlendMode() {
+	i.hasBlend = false
+}
+
+// SetTint sets a colour that this image is multiplied by
+// whenever it is drawn. Use nil for no tint.
+func (i *Image) SetTint(tint *Color) {
+	i.tint = tint
+}
+
+// SetAlpha sets the opacity of this image, from 0 for
+// invisible to 255 for fully opaque.
+func (i *Image) SetAlpha(alpha uint8) {
+	i.fade = 255 - alpha
+}
+
+// GetAlpha returns the opacity of this image
+func (i *Image) GetAlpha() uint8 {
+	return 255 - i.fade
+}
+
+// blendMode returns the blend mode to draw the
+// image with on the given renderer.
+func (i *Image) blendMode(r *Renderer) BlendMode {
+	if i.hasBlend {
+		return i.blend
+	}
+	return r.blend
+}
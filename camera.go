@@ -0,0 +1,96 @@
+package strife
+
+// Camera looks onto a 2D world. The point X, Y in the world
+// is shown at the centre of the cameras viewport.
+// Zoom => How much the world is scaled by, zero is treated as 1;
+// Rotation => How far the camera is turned in degrees; and
+// Viewport => The area of the screen that the camera draws into,
+// if it is empty then the whole renderer is used.
+type Camera struct {
+	X, Y     float64
+	Zoom     float64
+	Rotation float64
+	Viewport Rectangle
+}
+
+// NewCamera creates a camera that draws into the given viewport
+// looking at the origin of the world.
+func NewCamera(viewport Rectangle) *Camera {
+	return &Camera{
+		Zoom:     1,
+		Viewport: viewport,
+	}
+}
+
+// LookAt moves the camera to look at the given world
+// co-ordinates
+func (c *Camera) LookAt(x, y float64) {
+	c.X, c.Y = x, y
+}
+
+func (c *Camera) zoom() float64 {
+	if c.Zoom == 0 {
+		return 1
+	}
+	return c.Zoom
+}
+
+// viewport returns the viewport of the camera, if it
+// is empty then this is the size of the renderer.
+func (c *Camera) viewport() Rectangle {
+	if c.Viewport.W > 0 && c.Viewport.H > 0 {
+		return c.Viewport
+	}
+	if RenderInstance != nil {
+		w, h := RenderInstance.GetSize()
+		return Rectangle{0, 0, w, h}
+	}
+	return Rectangle{}
+}
+
+// Matrix returns the transform that maps world
+// co-ordinates to the screen.
+func (c *Camera) Matrix() Matrix {
+	view := c.viewport()
+	zoom := c.zoom()
+	return Translation(float64(view.X)+float64(view.W)/2, float64(view.Y)+float64(view.H)/2).
+		Multiply(Rotation(-c.Rotation)).
+		Multiply(Scaling(zoom, zoom)).
+		Multiply(Translation(-c.X, -c.Y))
+}
+
+// WorldToScreen maps the world co-ordinates x, y
+// to where they are shown on the screen.
+func (c *Camera) WorldToScreen(x, y float64) (float64, float64) {
+	return c.Matrix().Apply(x, y)
+}
+
+// ScreenToWorld maps the screen co-ordinates x, y
+// into the world, e.g. to find what the mouse is over:
+//
+//	mx, my := strife.MouseCoords()
+//	wx, wy := camera.ScreenToWorld(float64(mx), float64(my))
+func (c *Camera) ScreenToWorld(x, y float64) (float64, float64) {
+	inv, ok := c.Matrix().Invert()
+	if !ok {
+		return x, y
+	}
+	return inv.Apply(x, y)
+}
+
+// WithCamera will run the given draw function with everything
+// drawn in world co-ordinates as seen through the given camera.
+// Drawing is clipped to the viewport of the camera with PushClip.
+func (r *Renderer) WithCamera(cam *Camera, draw func()) {
+	view := cam.viewport()
+	r.PushClip(view.X, view.Y, view.W, view.H)
+
+	r.Push()
+	r.transform = r.transform.Multiply(cam.Matrix())
+	defer func() {
+		r.Pop()
+		r.PopClip()
+	}()
+
+	draw()
+}
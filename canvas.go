@@ -0,0 +1,96 @@
+package strife
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Canvas is an off-screen image that can be rendered into.
+// While a canvas is set as the target of the renderer with
+// SetTarget all drawing goes into the canvas rather than the window.
+// As a canvas wraps an Image it can be drawn with Renderer.Image,
+// e.g. r.Image(canvas.Image, x, y). Note that a canvas has no
+// surface, it only exists on the GPU.
+//
+// Whatever is drawn into a canvas is blended with its transparent
+// pixels, so its colours end up multiplied by their alpha. The canvas
+// is drawn in a way that accounts for this so that translucent things
+// look the same as if they were drawn directly, except on renderers
+// that can't, see Premultiplied.
+type Canvas struct {
+	*Image
+}
+
+// NewCanvas will create a canvas of the given size. The
+// canvas starts off fully transparent. It will return the
+// canvas and any errors encountered.
+func NewCanvas(w, h int) (*Canvas, error) {
+	if RenderInstance == nil {
+		return nil, fmt.Errorf("Render context has not been initialized yet.")
+	}
+	if !RenderInstance.RenderTargetSupported() {
+		return nil, fmt.Errorf("Render targets are not supported by this renderer")
+	}
+
+	texture, err := RenderInstance.CreateTexture(sdl.PIXELFORMAT_RGBA8888, sdl.TEXTUREACCESS_TARGET, int32(w), int32(h))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create canvas of size %dx%d", w, h)
+	}
+	RenderInstance.textureAllocated()
+
+	canvas := &Canvas{newImage(texture, nil, w, h)}
+	canvas.premultiplied = texture.SetBlendMode(blendPremultiplied) == nil
+	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+
+	// textures are not cleared when they are created
+	// so we clear it ourselves.
+	prev := RenderInstance.GetRenderTarget()
+	RenderInstance.SetRenderTarget(texture)
+	RenderInstance.SetDrawColor(0, 0, 0, 0)
+	RenderInstance.Renderer.Clear()
+	RenderInstance.SetRenderTarget(prev)
+	RenderInstance.applyClip()
+	RenderInstance.applyDrawColor()
+
+	return canvas, nil
+}
+
+// Premultiplied reports if the canvas is drawn with its colours
+// already multiplied by their alpha. If the renderer can't, e.g. the
+// software renderer, the alpha of translucent pixels in the canvas is
+// applied twice when it is drawn so they come out darker.
+func (c *Canvas) Premultiplied() bool {
+	return c.premultiplied
+}
+
+// SetTarget will direct all drawing into the given canvas
+// until ResetTarget is called.
+func (r *Renderer) SetTarget(canvas *Canvas) error {
+	if err := r.SetRenderTarget(canvas.Texture); err != nil {
+		return err
+	}
+	r.target = canvas
+	return nil
+}
+
+// ResetTarget will direct all drawing back to the window,
+// or to the logical canvas if a logical size is set.
+func (r *Renderer) ResetTarget() error {
+	var texture *sdl.Texture
+	if r.logical != nil {
+		texture = r.logical.Texture
+	}
+	if err := r.SetRenderTarget(texture); err != nil {
+		return err
+	}
+	r.target = nil
+	r.applyClip()
+	return nil
+}
+
+// GetTarget returns the canvas that is currently being
+// drawn into, or nil if drawing to the window.
+func (r *Renderer) GetTarget() *Canvas {
+	return r.target
+}
@@ -0,0 +1,53 @@
+package strife_test
+
+import (
+	"testing"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/strifetest"
+)
+
+func TestCanvasTranslucency(t *testing.T) {
+	window := strifetest.NewWindow(t, 8, 8)
+	ctx := window.GetRenderContext()
+	canvas, err := strife.NewCanvas(8, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer canvas.Destroy()
+	if !canvas.Premultiplied() {
+		t.Skip("Renderer can't draw canvases premultiplied")
+	}
+
+	half := &strife.Color{R: 255, G: 255, B: 255, A: 128}
+	ctx.Clear()
+	ctx.SetColor(half)
+	ctx.Rect(0, 0, 8, 8, strife.Fill)
+	want, err := ctx.Screenshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same rectangle drawn through a canvas
+	// must be blended with the screen only once.
+	ctx.Clear()
+	if err := ctx.SetTarget(canvas); err != nil {
+		t.Fatal(err)
+	}
+	ctx.SetColor(half)
+	ctx.Rect(0, 0, 8, 8, strife.Fill)
+	ctx.ResetTarget()
+	ctx.Image(canvas.Image, 0, 0)
+
+	got, err := ctx.Screenshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := strifetest.Compare(got, want, strifetest.Tolerance{Channel: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Different > 0 {
+		t.Errorf("%d pixels are different by up to %d", result.Different, result.MaxDelta)
+	}
+}
@@ -0,0 +1,92 @@
+package strife
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PushClip will restrict drawing to the given rectangle until
+// PopClip is called. The rectangle is in the co-ordinates of the
+// current transform, if the transform rotates then drawing is clipped
+// to the box around the rotated rectangle. Clips can be nested, and
+// each clip is cut down to fit inside of the clip it is pushed onto.
+// If there is nothing left of the clip then nothing is drawn.
+func (r *Renderer) PushClip(x, y, w, h int) {
+	clip := r.screenBounds(Rectangle{x, y, w, h})
+	if len(r.clips) > 0 {
+		clip = intersectRects(r.clips[len(r.clips)-1], clip)
+	}
+	r.clips = append(r.clips, clip)
+	r.SetClipRect(clip)
+}
+
+// applyClip passes the current clip through to SDL, it is
+// needed after changing the target as SDL resets the clip.
+func (r *Renderer) applyClip() {
+	if len(r.clips) == 0 {
+		r.SetClipRect(nil)
+		return
+	}
+	r.SetClipRect(r.clips[len(r.clips)-1])
+}
+
+// clippedAway checks if the current clip is empty, in which case
+// nothing can be drawn. SDL treats an empty clip as no clip at all
+// so drawing has to be skipped rather than left to SDL.
+func (r *Renderer) clippedAway() bool {
+	if len(r.clips) == 0 {
+		return false
+	}
+	clip := r.clips[len(r.clips)-1]
+	return clip.W <= 0 || clip.H <= 0
+}
+
+// PopClip removes the last clip that was pushed with
+// PushClip, restoring the clip before it.
+func (r *Renderer) PopClip() {
+	if len(r.clips) == 0 {
+		panic("PopClip called without a matching PushClip")
+	}
+	r.clips = r.clips[:len(r.clips)-1]
+	r.applyClip()
+}
+
+// GetClip returns the current clip in screen co-ordinates,
+// if nothing is being clipped it returns false.
+func (r *Renderer) GetClip() (Rectangle, bool) {
+	if len(r.clips) == 0 {
+		return Rectangle{}, false
+	}
+	clip := r.clips[len(r.clips)-1]
+	return Rectangle{int(clip.X), int(clip.Y), int(clip.W), int(clip.H)}, true
+}
+
+// intersectRects returns the area where the two rectangles
+// overlap, which has no width or height if they don't overlap.
+func intersectRects(a, b *sdl.Rect) *sdl.Rect {
+	x0, y0 := maxInt32(a.X, b.X), maxInt32(a.Y, b.Y)
+	x1, y1 := minInt32(a.X+a.W, b.X+b.W), minInt32(a.Y+a.H, b.Y+b.H)
+	return &sdl.Rect{x0, y0, maxInt32(x1-x0, 0), maxInt32(y1-y0, 0)}
+}
+
+// screenBounds returns the smallest rectangle on screen
+// that covers the given rectangle under the current transform.
+func (r *Renderer) screenBounds(rect Rectangle) *sdl.Rect {
+	x0, y0 := float64(rect.X), float64(rect.Y)
+	x1, y1 := x0+float64(rect.W), y0+float64(rect.H)
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range []Point{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}} {
+		x, y := r.transform.Apply(p.X, p.Y)
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+
+	left, top := math.Floor(minX), math.Floor(minY)
+	return &sdl.Rect{
+		int32(left), int32(top),
+		int32(math.Ceil(maxX) - left), int32(math.Ceil(maxY) - top),
+	}
+}
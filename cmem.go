@@ -0,0 +1,29 @@
+package strife
+
+// #include <stdlib.h>
+import "C"
+
+import "unsafe"
+
+// cBytes is a copy of some bytes in C memory. SDL can keep
+// pointers into it after a call returns, which it can't do
+// with Go memory as the garbage collector doesn't know about
+// them. It must be freed when SDL is finished with it.
+type cBytes struct {
+	ptr unsafe.Pointer
+	n   int
+}
+
+// newCBytes copies the given bytes into C memory
+func newCBytes(data []byte) cBytes {
+	return cBytes{C.CBytes(data), len(data)}
+}
+
+// bytes returns the C memory as a slice, without copying it
+func (b cBytes) bytes() []byte {
+	return (*[1 << 30]byte)(b.ptr)[:b.n:b.n]
+}
+
+func (b cBytes) free() {
+	C.free(b.ptr)
+}
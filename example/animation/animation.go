@@ -0,0 +1,58 @@
+package main
+
+import (
+	"time"
+
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Animation!")
+	window.SetResizable(true)
+	window.Create()
+
+	masterpiece, err := strife.LoadImage("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+
+	// treat the image as a 4x4 grid of frames
+	sheet := strife.NewSpriteSheet(masterpiece, masterpiece.Width/4, masterpiece.Height/4)
+
+	loop := strife.NewAnimation(sheet, strife.Loop)
+	pingPong := strife.NewAnimation(sheet, strife.PingPong, 0, 1, 2, 3)
+	pingPong.Speed = 0.5
+	once := strife.NewAnimation(sheet, strife.Once, 4, 5, 6, 7)
+
+	frameW := masterpiece.Width / 4
+	last := time.Now()
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		now := time.Now()
+		delta := now.Sub(last)
+		last = now
+
+		loop.Update(delta)
+		pingPong.Update(delta)
+		once.Update(delta)
+		if once.Finished() {
+			once.Reset()
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.Animation(loop, 20, 20)
+			ctx.Animation(pingPong, 40+frameW, 20)
+			ctx.Animation(once, 60+frameW*2, 20)
+		}
+		ctx.Display()
+	}
+
+	masterpiece.Destroy()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Assets!")
+	window.Create()
+
+	ctx := window.GetRenderContext()
+
+	// edit and save the image while this is
+	// running to see it change.
+	assets := strife.NewAssets()
+	assets.HotReload = true
+
+	// both handles share the same image
+	first, err := assets.Image("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+	second, err := assets.Image("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		if err := assets.Update(); err != nil {
+			println(err.Error())
+		}
+
+		ctx.Clear()
+		{
+			ctx.ImageScale(first.Image, 20, 20, first.Width/2, first.Height/2)
+			ctx.ImageScale(second.Image, 640, 20, second.Width/4, second.Height/4)
+		}
+		ctx.Display()
+	}
+
+	first.Release()
+	second.Release()
+	assets.Destroy()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/felixangell/strife"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Atlas!")
+	window.SetResizable(true)
+	window.Create()
+
+	masterpiece, err := strife.LoadImage("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+
+	atlas := strife.NewAtlas(1024, 1024)
+	if err := atlas.AddImage("masterpiece", masterpiece); err != nil {
+		panic(err)
+	}
+
+	// some coloured blocks of different sizes to
+	// fill up the rest of the pages.
+	for i := 0; i < 40; i++ {
+		size := int32(16 + (i*37)%96)
+		block, err := sdl.CreateRGBSurfaceWithFormat(0, size, size*2/3, 32, uint32(sdl.PIXELFORMAT_RGBA32))
+		if err != nil {
+			panic(err)
+		}
+		block.FillRect(nil, sdl.MapRGBA(block.Format, uint8(i*60), uint8(i*25), 200, 255))
+
+		// the atlas copies the pixels so the
+		// block can be freed straight away.
+		err = atlas.AddSurface(fmt.Sprintf("block%d", i), block)
+		block.Free()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if err := atlas.Build(); err != nil {
+		panic(err)
+	}
+	fmt.Println("pages:", len(atlas.Pages()), "occupancy:", atlas.Occupancy())
+
+	art, _ := atlas.Sprite("masterpiece")
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.SpriteScale(art, 20, 20, art.W/4, art.H/4)
+
+			for i := 0; i < 40; i++ {
+				block, _ := atlas.Sprite(fmt.Sprintf("block%d", i))
+				ctx.Sprite(block, 400+(i%8)*110, 20+(i/8)*110)
+			}
+		}
+		ctx.Display()
+	}
+
+	atlas.Destroy()
+	masterpiece.Destroy()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Blending!")
+	window.Create()
+
+	masterpiece, err := strife.LoadImage("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+
+	var frame int
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			// overlapping circles glow where they
+			// are added together.
+			ctx.SetBlendMode(strife.BlendAdd)
+			ctx.SetColor(strife.Red)
+			ctx.Circle(200, 200, 100, strife.Fill)
+			ctx.SetColor(strife.Green)
+			ctx.Circle(300, 200, 100, strife.Fill)
+			ctx.SetColor(strife.Blue)
+			ctx.Circle(250, 290, 100, strife.Fill)
+			ctx.SetBlendMode(strife.BlendAlpha)
+
+			// the image fades in and out, and flashes
+			// red every now and then.
+			masterpiece.SetAlpha(uint8(frame % 256))
+			if frame%60 < 5 {
+				masterpiece.SetTint(strife.Red)
+			} else {
+				masterpiece.SetTint(nil)
+			}
+			ctx.ImageScale(masterpiece, 600, 100, masterpiece.Width/4, masterpiece.Height/4)
+		}
+		ctx.Display()
+
+		frame++
+	}
+
+	masterpiece.Destroy()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Camera!")
+	window.Create()
+
+	camera := strife.NewCamera(strife.Rectangle{X: 40, Y: 40, W: 1200, H: 640})
+
+	window.HandleEvents(func(evt strife.StrifeEvent) {
+		switch event := evt.(type) {
+		case *strife.CloseEvent:
+			window.Close()
+		case *strife.MouseWheelEvent:
+			camera.Zoom *= 1 + float64(event.Y)*0.1
+		}
+	})
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		if strife.KeyPressed(strife.KEY_W) {
+			camera.Y -= 4
+		}
+		if strife.KeyPressed(strife.KEY_S) {
+			camera.Y += 4
+		}
+		if strife.KeyPressed(strife.KEY_A) {
+			camera.X -= 4
+		}
+		if strife.KeyPressed(strife.KEY_D) {
+			camera.X += 4
+		}
+		if strife.KeyPressed(strife.KEY_Q) {
+			camera.Rotation -= 1
+		}
+		if strife.KeyPressed(strife.KEY_E) {
+			camera.Rotation += 1
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.WithCamera(camera, func() {
+				for x := -10; x <= 10; x++ {
+					for y := -10; y <= 10; y++ {
+						if (x+y)%2 == 0 {
+							ctx.SetColor(strife.Blue)
+						} else {
+							ctx.SetColor(strife.Green)
+						}
+						ctx.Rect(x*64, y*64, 64, 64, strife.Fill)
+					}
+				}
+
+				// highlight the tile under the mouse.
+				mx, my := strife.MouseCoords()
+				wx, wy := camera.ScreenToWorld(float64(mx), float64(my))
+				ctx.SetColor(strife.Red)
+				ctx.Circle(int(wx), int(wy), 8, strife.Fill)
+			})
+
+			ctx.SetColor(strife.White)
+			ctx.Text("WASD to move, QE to rotate, scroll to zoom", 40, 4)
+		}
+		ctx.Display()
+	}
+}
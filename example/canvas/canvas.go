@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Canvas!")
+	window.Create()
+
+	ctx := window.GetRenderContext()
+
+	// draw the minimap once into a canvas
+	// and re-use it every frame.
+	minimap, err := strife.NewCanvas(200, 200)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetTarget(minimap)
+	ctx.SetColor(strife.Green)
+	ctx.RoundedRect(0, 0, 200, 200, 16, strife.Fill)
+	ctx.SetColor(strife.Red)
+	ctx.Circle(60, 80, 10, strife.Fill)
+	ctx.Circle(140, 120, 10, strife.Fill)
+	ctx.ResetTarget()
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx.Clear()
+		{
+			ctx.Image(minimap.Image, 20, 20)
+			ctx.ImageScale(minimap.Image, 300, 20, 400, 400)
+		}
+		ctx.Display()
+	}
+
+	minimap.Destroy()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Clipping!")
+	window.Create()
+
+	var scroll int
+
+	window.HandleEvents(func(evt strife.StrifeEvent) {
+		switch event := evt.(type) {
+		case *strife.CloseEvent:
+			window.Close()
+		case *strife.MouseWheelEvent:
+			scroll += event.Y * 16
+		}
+	})
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			// the outer panel
+			ctx.SetColor(strife.RGB(40, 40, 40))
+			ctx.Rect(100, 100, 400, 400, strife.Fill)
+			ctx.PushClip(100, 100, 400, 400)
+
+			// a scrolling list inside of the panel
+			ctx.Push()
+			ctx.Translate(120, float64(120+scroll))
+			for i := 0; i < 50; i++ {
+				ctx.SetColor(strife.White)
+				ctx.Text(fmt.Sprintf("item %d", i), 0, i*32)
+			}
+			ctx.Pop()
+
+			// a nested clip only shows the part that
+			// overlaps with the panel.
+			ctx.PushClip(400, 400, 200, 200)
+			ctx.SetColor(strife.Red)
+			ctx.Circle(450, 450, 80, strife.Fill)
+			ctx.PopClip()
+
+			ctx.PopClip()
+		}
+		ctx.Display()
+	}
+}
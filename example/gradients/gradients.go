@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Gradients!")
+	window.Create()
+
+	sky := strife.NewLinearGradient(0, 0, 0, 720,
+		strife.ColorStop{Offset: 0, Color: strife.RGB(20, 24, 82)},
+		strife.ColorStop{Offset: 0.6, Color: strife.RGB(240, 120, 60)},
+		strife.ColorStop{Offset: 1, Color: strife.RGB(255, 220, 140)},
+	)
+
+	vignette := strife.NewRadialGradient(640, 360, 760,
+		strife.ColorStop{Offset: 0.5, Color: &strife.Color{}},
+		strife.ColorStop{Offset: 1, Color: &strife.Color{A: 200}},
+	)
+
+	highlight := strife.NewLinearGradient(0, 300, 0, 360,
+		strife.ColorStop{Offset: 0, Color: strife.RGB(120, 180, 255)},
+		strife.ColorStop{Offset: 1, Color: strife.RGB(40, 80, 200)},
+	)
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			w, h := ctx.GetSize()
+
+			ctx.SetPaint(sky)
+			ctx.Rect(0, 0, w, h, strife.Fill)
+
+			ctx.SetPaint(highlight)
+			ctx.RoundedRect(540, 300, 200, 60, 12, strife.Fill)
+
+			ctx.SetPaint(vignette)
+			ctx.Rect(0, 0, w, h, strife.Fill)
+
+			ctx.SetPaint(sky)
+			ctx.Text("Hello, gradients!", 540, 400)
+		}
+		ctx.Display()
+	}
+}
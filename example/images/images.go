@@ -1,9 +1,17 @@
 package main
 
 import (
+	"embed"
+
 	"github.com/felixangell/strife"
 )
 
+// the images are built into the binary so
+// it can be run from anywhere.
+//
+//go:embed res
+var res embed.FS
+
 func main() {
 	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
 	window.SetTitle("Hello world!")
@@ -20,13 +28,25 @@ func main() {
 		}
 	})
 
-	masterpiece, err := strife.LoadImage("./res/masterpiece.png")
+	masterpiece, err := strife.LoadImageFS(res, "res/masterpiece.png")
 	if err != nil {
 		panic(err)
 	}
 	scaledW := masterpiece.Width / 4
 	scaledH := masterpiece.Height / 4
 
+	// a small copy of the image turned on its side,
+	// made once on the CPU rather than every frame.
+	thumbnail, err := masterpiece.Resize(scaledW/2, scaledH/2, strife.ResizeBilinear)
+	if err != nil {
+		panic(err)
+	}
+	rotated, err := thumbnail.Rotate90()
+	if err != nil {
+		panic(err)
+	}
+	thumbnail.Destroy()
+
 	var x, y int
 	var dx, dy float64 = 6, 6
 
@@ -58,12 +78,25 @@ func main() {
 			ctx.Rect(50, 50, 50, 50, strife.Fill)
 
 			ctx.ImageScale(masterpiece, x, y, scaledW, scaledH)
+			ctx.Image(rotated, 20, 120)
 
 			// renders some arbitrary section of the image
 			ctx.SubImage(masterpiece, 500, 40, 50, 50, 90, 40)
+
+			// spins the image around its centre, mirrored
+			// and faded out a bit.
+			ctx.DrawImage(masterpiece, strife.DrawOptions{
+				X: 640, Y: 360,
+				ScaleX: 0.25, ScaleY: 0.25,
+				Rotation: float64(x),
+				Origin:   strife.Point{X: float64(masterpiece.Width) / 2, Y: float64(masterpiece.Height) / 2},
+				FlipH:    dx < 0,
+				Tint:     &strife.Color{R: 255, G: 200, B: 200, A: 180},
+			})
 		}
 		ctx.Display()
 	}
 
+	rotated.Destroy()
 	masterpiece.Destroy()
 }
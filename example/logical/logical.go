@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Logical size!")
+	window.SetResizable(true)
+	window.Create()
+
+	// the game is drawn at 320x180 and scaled up by whole
+	// numbers to fit the window, try resizing it.
+	ctx := window.GetRenderContext()
+	if err := ctx.SetLogicalResolution(320, 180, strife.ScaleInteger); err != nil {
+		panic(err)
+	}
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx.Clear()
+		{
+			w, h := ctx.GetSize()
+
+			ctx.SetColor(strife.Blue)
+			ctx.Rect(0, h-20, w, 20, strife.Fill)
+
+			ctx.SetColor(strife.Green)
+			ctx.Circle(40, h-30, 10, strife.Fill)
+
+			// the mouse is already in logical co-ordinates
+			mx, my := strife.MouseCoords()
+			ctx.SetColor(strife.Red)
+			ctx.Rect(mx-4, my, 9, 1, strife.Fill)
+			ctx.Rect(mx, my-4, 1, 9, strife.Fill)
+		}
+		ctx.Display()
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Nine slice!")
+	window.SetResizable(true)
+	window.Create()
+
+	frame, err := strife.LoadImage("../images/res/masterpiece.png")
+	if err != nil {
+		panic(err)
+	}
+
+	inset := frame.Width / 4
+	stretched := strife.NewNineSlice(frame, inset, inset, inset, inset)
+	tiled := strife.NewNineSlice(frame, inset, inset, inset, inset)
+	tiled.Mode = strife.Tile
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			// the panels follow the mouse so you can
+			// see how they resize.
+			mx, my := strife.MouseCoords()
+			ctx.NineSlice(stretched, 20, 20, mx/2, my)
+			ctx.NineSlice(tiled, 40+mx/2, 20, mx/2, my)
+		}
+		ctx.Display()
+	}
+
+	frame.Destroy()
+}
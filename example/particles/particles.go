@@ -1,22 +1,11 @@
 package main
 
 import (
-	"math/rand"
+	"time"
 
 	"github.com/felixangell/strife"
 )
 
-type particle struct {
-	x, y   float64
-	dx, dy float64
-	colour uint32
-	s      int
-}
-
-func randFloat(max float64, min float64) float64 {
-	return (rand.Float64() * (max - min)) + min
-}
-
 func main() {
 	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
 	window.SetTitle("Hello world!")
@@ -33,49 +22,59 @@ func main() {
 		}
 	})
 
-	const numParticles int = 1000
-	const speedCap float64 = 4.0
-
-	w, h := window.GetSize()
+	// the fire is defined in json so that it can
+	// be tweaked without recompiling.
+	fire, err := strife.LoadParticleEmitter("./res/fire.json")
+	if err != nil {
+		panic(err)
+	}
 
-	particles := [numParticles]*particle{}
-	for i := 0; i < numParticles; i++ {
-		size := rand.Intn(32) + 8
-		particles[i] = &particle{
-			x:      randFloat(0, float64(w-size)),
-			y:      randFloat(0, float64(h-size)),
-			dx:     randFloat(-(speedCap), speedCap),
-			dy:     randFloat(-(speedCap), speedCap),
-			s:      size,
-			colour: uint32(rand.Intn(0xffffff) / 0xff),
-		}
+	// the sparks follow the mouse, and burst
+	// when the mouse is clicked.
+	sparks := strife.NewParticleEmitter(0, 0)
+	sparks.Rate = 200
+	sparks.Lifetime = strife.Range{Min: 0.5, Max: 2}
+	sparks.Speed = strife.Range{Min: 50, Max: 250}
+	sparks.Gravity = strife.Point{X: 0, Y: 400}
+	sparks.Colors = []strife.ColorStop{
+		{Offset: 0, Color: strife.RGB(120, 200, 255)},
+		{Offset: 1, Color: strife.Blue},
 	}
+	sparks.Alpha = strife.NewCurve(strife.CurvePoint{Offset: 0.5, Value: 1}, strife.CurvePoint{Offset: 1, Value: 0})
+	sparks.Size = strife.NewCurve(strife.CurvePoint{Offset: 0, Value: 6}, strife.CurvePoint{Offset: 1, Value: 2})
 
+	last := time.Now()
 	for {
 		window.PollEvents()
 		if window.CloseRequested() {
 			break
 		}
 
-		ctx := window.GetRenderContext()
-		ctx.SetColor(strife.White)
-		ctx.Rect(0, 0, w, h, strife.Fill)
+		now := time.Now()
+		delta := now.Sub(last)
+		last = now
 
-		{
-			for _, p := range particles {
-				p.x += p.dx
-				p.y += p.dy
-				if p.x < 0 || int(p.x) >= w-p.s {
-					p.dx *= -1
-				}
-				if p.y < 0 || int(p.y) >= h-p.s {
-					p.dy *= -1
-				}
+		w, h := window.GetSize()
+		fire.X, fire.Y = float64(w)/2, float64(h)-40
 
-				ctx.SetColor(strife.HexRGB(p.colour))
-				ctx.Rect(int(p.x), int(p.y), p.s, p.s, strife.Fill)
-			}
+		mx, my := strife.MouseCoords()
+		sparks.X, sparks.Y = float64(mx), float64(my)
+		if strife.MouseButtonsState() == strife.LeftMouseButton {
+			sparks.Burst(50)
+		}
+
+		fire.Update(delta)
+		sparks.Update(delta)
+
+		ctx := window.GetRenderContext()
+		ctx.SetColor(strife.Black)
+		ctx.Clear()
+		{
+			ctx.Particles(fire)
+			ctx.Particles(sparks)
 		}
 		ctx.Display()
 	}
+
+	fire.Destroy()
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Pixels!")
+	window.Create()
+
+	ctx := window.GetRenderContext()
+
+	// a checkerboard made with the image package
+	// and turned into a strife image.
+	board := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if (x/8+y/8)%2 == 0 {
+				board.Set(x, y, color.NRGBA{255, 255, 255, 255})
+			} else {
+				board.Set(x, y, color.NRGBA{40, 40, 40, 255})
+			}
+		}
+	}
+	checkers, err := strife.NewImageFromGo(board)
+	if err != nil {
+		panic(err)
+	}
+
+	// a plasma that is generated every frame
+	plasma, err := strife.NewPixelImage(160, 120)
+	if err != nil {
+		panic(err)
+	}
+
+	start := time.Now()
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		t := time.Since(start).Seconds()
+		pixels := plasma.Lock()
+		for y := 0; y < plasma.Height; y++ {
+			for x := 0; x < plasma.Width; x++ {
+				v := math.Sin(float64(x)/16+t) + math.Sin(float64(y)/8+t*1.3) + math.Sin(float64(x+y)/24+t*0.7)
+				i := pixels.PixOffset(x, y)
+				pixels.Pix[i+0] = uint8(128 + 127*math.Sin(v*math.Pi))
+				pixels.Pix[i+1] = uint8(128 + 127*math.Sin(v*math.Pi+2))
+				pixels.Pix[i+2] = uint8(128 + 127*math.Sin(v*math.Pi+4))
+				pixels.Pix[i+3] = 255
+			}
+		}
+		plasma.Unlock()
+
+		ctx.Clear()
+		{
+			ctx.ImageScale(checkers, 20, 20, 256, 256)
+			ctx.ImageScale(plasma.Image, 300, 20, 640, 480)
+		}
+		ctx.Display()
+	}
+
+	plasma.Destroy()
+	checkers.Destroy()
+}
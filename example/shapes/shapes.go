@@ -0,0 +1,91 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Shapes!")
+	window.SetResizable(true)
+	window.Create()
+
+	showStats := false
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.SetColor(strife.Red)
+			ctx.Circle(100, 100, 50, strife.Fill)
+			ctx.Circle(220, 100, 50, strife.Line)
+
+			ctx.SetColor(strife.Green)
+			ctx.Ellipse(380, 100, 80, 40, strife.Fill)
+			ctx.Ellipse(380, 220, 80, 40, strife.Line)
+
+			ctx.SetColor(strife.Blue)
+			ctx.Arc(100, 260, 50, 30, 330, strife.Fill)
+			ctx.Arc(220, 260, 50, 180, 360, strife.Line)
+
+			chevron := []strife.Point{
+				{X: 500, Y: 200}, {X: 560, Y: 260}, {X: 500, Y: 320},
+				{X: 520, Y: 260},
+			}
+			ctx.SetColor(strife.White)
+			ctx.Polygon(chevron, strife.Fill)
+
+			ctx.Triangles([]strife.Vertex{
+				{X: 600, Y: 320, Color: strife.Red},
+				{X: 660, Y: 200, Color: strife.Green},
+				{X: 720, Y: 320, Color: strife.Blue},
+			})
+
+			ctx.SetColor(strife.White)
+			ctx.Line(50, 400, 250, 450, strife.Stroke{Width: 8, Cap: strife.RoundCap})
+			ctx.Polyline([]strife.Point{
+				{X: 300, Y: 450}, {X: 350, Y: 380}, {X: 400, Y: 450}, {X: 450, Y: 380},
+			}, strife.Stroke{Width: 12, Join: strife.MiterJoin, Cap: strife.SquareCap})
+
+			ctx.SetColor(strife.Green)
+			ctx.RoundedRect(500, 380, 200, 100, 16, strife.Fill)
+			ctx.RoundedRectCorners(750, 380, 200, 100, strife.Corners{
+				TopLeft: 32, BottomRight: 32,
+			}, strife.Line)
+
+			wire := strife.NewPath()
+			wire.MoveTo(50, 550)
+			wire.CubicTo(250, 550, 250, 680, 450, 680)
+			ctx.SetColor(strife.White)
+			ctx.StrokePath(wire, strife.Stroke{Width: 3, Cap: strife.RoundCap})
+
+			badge := strife.NewPath()
+			badge.MoveTo(600, 520)
+			badge.ArcTo(700, 520, 700, 620, 20)
+			badge.QuadTo(700, 680, 600, 680)
+			badge.Close()
+			ctx.SetColor(strife.Blue)
+			ctx.FillPath(badge)
+		}
+
+		// F12 saves a screenshot, this has to be
+		// done before the frame is displayed. F3
+		// toggles the stats overlay.
+		for strife.PollKeys() {
+			switch strife.PopKey() {
+			case strife.KEY_F12:
+				if err := ctx.SaveScreenshot("shapes.png"); err != nil {
+					println(err.Error())
+				}
+			case strife.KEY_F3:
+				showStats = !showStats
+				ctx.ShowStats(showStats)
+			}
+		}
+		ctx.Display()
+	}
+}
@@ -0,0 +1,4 @@
+<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" name="masterpiece" tilewidth="45" tileheight="47" tilecount="126" columns="9">
+ <image source="../../images/res/masterpiece.png" width="405" height="658"/>
+</tileset>
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/tilemap"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Tile map!")
+	window.Create()
+
+	level, err := tilemap.Load("./res/level.tmx")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("loaded", level.Properties["title"])
+
+	camera := strife.NewCamera(strife.Rectangle{X: 0, Y: 0, W: 1280, H: 720})
+	for _, group := range level.ObjectGroups {
+		for _, obj := range group.Objects {
+			if obj.Type == "spawn" {
+				camera.LookAt(obj.X, obj.Y)
+			}
+		}
+	}
+
+	ground := level.Layers[0]
+
+	window.HandleEvents(func(evt strife.StrifeEvent) {
+		switch event := evt.(type) {
+		case *strife.CloseEvent:
+			window.Close()
+		case *strife.MouseWheelEvent:
+			camera.Zoom *= 1 + float64(event.Y)*0.1
+		}
+	})
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		if strife.KeyPressed(strife.KEY_W) {
+			camera.Y -= 4
+		}
+		if strife.KeyPressed(strife.KEY_S) {
+			camera.Y += 4
+		}
+		if strife.KeyPressed(strife.KEY_A) {
+			camera.X -= 4
+		}
+		if strife.KeyPressed(strife.KEY_D) {
+			camera.X += 4
+		}
+
+		// clear the tile under the mouse, only the chunk
+		// that it is in is drawn again.
+		if strife.MouseButtonsState() == strife.LeftMouseButton {
+			mx, my := strife.MouseCoords()
+			wx, wy := camera.ScreenToWorld(float64(mx), float64(my))
+			ground.SetTile(int(wx)/level.TileWidth, int(wy)/level.TileHeight, 0)
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.WithCamera(camera, func() {
+				level.Draw(ctx)
+			})
+		}
+		ctx.Display()
+	}
+
+	level.Destroy()
+}
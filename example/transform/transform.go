@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/felixangell/strife"
+)
+
+func main() {
+	window := strife.SetupRenderWindow(1280, 720, strife.DefaultConfig())
+	window.SetTitle("Transforms!")
+	window.Create()
+
+	var angle float64
+
+	for {
+		window.PollEvents()
+		if window.CloseRequested() {
+			break
+		}
+
+		ctx := window.GetRenderContext()
+		ctx.Clear()
+		{
+			ctx.Push()
+			ctx.Translate(640, 360)
+			ctx.Rotate(angle)
+
+			ctx.SetColor(strife.Red)
+			ctx.Rect(-50, -50, 100, 100, strife.Fill)
+
+			// nested transforms are relative
+			// to their parent.
+			ctx.Push()
+			ctx.Translate(150, 0)
+			ctx.Scale(0.5, 0.5)
+			ctx.SetColor(strife.Blue)
+			ctx.Circle(0, 0, 50, strife.Fill)
+			ctx.SetColor(strife.White)
+			ctx.Text("orbit", -30, 60)
+			ctx.Pop()
+
+			ctx.Pop()
+
+			// find where the mouse is relative to the
+			// centre of the screen.
+			ctx.Push()
+			ctx.Translate(640, 360)
+			mx, my := strife.MouseCoords()
+			lx, ly := ctx.ToLocal(float64(mx), float64(my))
+			ctx.SetColor(strife.Green)
+			ctx.Line(0, 0, lx, ly, strife.Stroke{Width: 2})
+			ctx.Pop()
+		}
+		ctx.Display()
+
+		angle += 1
+	}
+}
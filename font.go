@@ -2,6 +2,7 @@ package strife
 
 import (
 	"fmt"
+	"io/fs"
 
 	"github.com/veandco/go-sdl2/sdl"
 	ttf "github.com/veandco/go-sdl2/ttf"
@@ -46,17 +47,25 @@ func encode(col uint32, style fontStyle, val rune) glyphInfo {
 	}
 }
 
-// Font is a TrueTypeFont, stores the path
-// as well as the texture cache for the glyphs
+// Font is a TrueTypeFont, stores the path, or the
+// font file if it was loaded from memory, as well
+// as the texture cache for the glyphs
 type Font struct {
 	*ttf.Font
 	path     string
 	texCache map[string]*glyph
+
+	// mem is the font file that SDL reads from,
+	// if the font was loaded from memory.
+	mem *cBytes
 }
 
 // DeriveFont will create a new font object from
 // this font of a different size.
 func (f *Font) DeriveFont(size int) (*Font, error) {
+	if f.mem != nil {
+		return LoadFontBytes(f.mem.bytes(), size)
+	}
 	return LoadFont(f.path, size)
 }
 
@@ -70,7 +79,6 @@ func (f *Font) hasGlyph(g glyphInfo) (*glyph, bool) {
 func (f *Font) cache(g glyphInfo, texture *sdl.Texture, dim []int32) *glyph {
 	// todo cache collision?
 	glyph := &glyph{texture, dim}
-	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
 	f.texCache[g.asKey()] = glyph
 	return glyph
 }
@@ -88,9 +96,54 @@ func LoadFont(path string, size int) (*Font, error) {
 	}
 
 	return &Font{
-		font,
-		path,
-		map[string]*glyph{},
+		Font:     font,
+		path:     path,
+		texCache: map[string]*glyph{},
+	}, nil
+}
+
+// LoadFontFS will try and load the font with the given
+// name from the file system, e.g. an embed.FS, of the
+// given size.
+func LoadFontFS(fsys fs.FS, name string, size int) (*Font, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load font at '%s'", name)
+	}
+	return LoadFontBytes(data, size)
+}
+
+// LoadFontBytes will try and load the font from the given
+// font file in memory of the given size. The data is copied
+// so it can be changed once the font is loaded.
+func LoadFontBytes(data []byte, size int) (*Font, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Failed to load font from memory, there is no data")
+	}
+	if !fontLoaderInitialized {
+		ttf.Init()
+	}
+
+	// the font keeps reading from the memory after it is
+	// opened, so it is read from a copy in C memory.
+	mem := newCBytes(data)
+	rw, err := sdl.RWFromMem(mem.bytes())
+	if err != nil {
+		mem.free()
+		return nil, fmt.Errorf("Failed to load font from memory")
+	}
+
+	// closing the font frees the RWops but not the memory.
+	font, err := ttf.OpenFontRW(rw, 1, size)
+	if err != nil {
+		mem.free()
+		return nil, fmt.Errorf("Failed to load font from memory")
+	}
+
+	return &Font{
+		Font:     font,
+		texCache: map[string]*glyph{},
+		mem:      &mem,
 	}, nil
 }
 
@@ -100,5 +153,15 @@ func (f *Font) Destroy() {
 	for _, glyph := range f.texCache {
 		glyph.tex.Destroy()
 	}
+	f.close()
+}
+
+// close closes the TTF font and frees the
+// memory it was being read from.
+func (f *Font) close() {
 	f.Font.Close()
+	if f.mem != nil {
+		f.mem.free()
+		f.mem = nil
+	}
 }
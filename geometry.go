@@ -0,0 +1,386 @@
+package strife
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Point is a position in 2D space
+type Point struct {
+	X, Y float64
+}
+
+// Rectangle is an area of the given size with
+// its top left corner at X, Y
+type Rectangle struct {
+	X, Y, W, H int
+}
+
+// Vertex is a corner of a triangle with its
+// own colour. If Color is nil then the renderers
+// current colour is used.
+type Vertex struct {
+	X, Y  float64
+	Color *Color
+}
+
+// Polygon will draw the polygon described by the given
+// points. The polygon is closed automatically, i.e. there is
+// no need to repeat the first point. It takes the mode to render
+// the polygon as: fill or line. Filled polygons can be concave,
+// but they must not intersect themselves.
+func (r *Renderer) Polygon(points []Point, mode Style) {
+	if len(points) < 2 {
+		return
+	}
+
+	if mode == Line {
+		lines := make([]sdl.FPoint, 0, len(points)+1)
+		for _, p := range points {
+			lines = append(lines, sdl.FPoint{float32(p.X), float32(p.Y)})
+		}
+		lines = append(lines, lines[0])
+		r.drawLines(lines)
+		return
+	}
+
+	r.fillTriangles(triangulate(points))
+}
+
+// Triangles will draw the given vertices as a list of filled
+// triangles, i.e. every three vertices make up a triangle. Each
+// vertex can have its own colour which is blended across the
+// triangle.
+func (r *Renderer) Triangles(vertices []Vertex) {
+	count := len(vertices) - len(vertices)%3
+	if count == 0 {
+		return
+	}
+
+	verts := make([]sdl.Vertex, count)
+	for i, v := range vertices[:count] {
+		col := v.Color
+		if col == nil {
+			col = r.color
+		}
+		verts[i] = sdl.Vertex{
+			Position: sdl.FPoint{float32(v.X), float32(v.Y)},
+			Color:    col.ToSDLColor(),
+		}
+	}
+	r.geometry(nil, verts)
+}
+
+// fillTriangles will fill the given list of triangles with the
+// current colour.
+func (r *Renderer) fillTriangles(tris []Point) {
+	if len(tris) == 0 {
+		return
+	}
+
+	if r.paint != nil {
+		r.geometry(nil, r.paint.shade(tris, nil))
+		return
+	}
+
+	col := r.color.ToSDLColor()
+	verts := make([]sdl.Vertex, len(tris))
+	for i, p := range tris {
+		verts[i] = sdl.Vertex{
+			Position: sdl.FPoint{float32(p.X), float32(p.Y)},
+			Color:    col,
+		}
+	}
+	r.geometry(nil, verts)
+}
+
+// geometry submits the given triangles to SDL, the texture
+// can be nil if the triangles are not textured.
+func (r *Renderer) geometry(texture *sdl.Texture, verts []sdl.Vertex) {
+	if r.clippedAway() {
+		return
+	}
+	if r.tint != nil || r.alpha != 255 {
+		for i, v := range verts {
+			verts[i].Color = r.modulate(v.Color)
+		}
+	}
+	if m := r.transform; m != Identity() {
+		for i, v := range verts {
+			x, y := m.Apply(float64(v.Position.X), float64(v.Position.Y))
+			verts[i].Position = sdl.FPoint{float32(x), float32(y)}
+		}
+	}
+	if err := r.RenderGeometry(texture, verts, nil); err != nil {
+		panic(err)
+	}
+	r.countDraw(texture)
+}
+
+// cross returns the z component of the cross product of
+// the vectors o->a and o->b.
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// signedArea returns twice the signed area of the polygon, it
+// is positive when the points wind clockwise on screen.
+func signedArea(points []Point) float64 {
+	var area float64
+	for i := range points {
+		a, b := points[i], points[(i+1)%len(points)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area
+}
+
+func inTriangle(p, a, b, c Point) bool {
+	return cross(a, b, p) >= 0 && cross(b, c, p) >= 0 && cross(c, a, p) >= 0
+}
+
+// triangulate will split the given simple polygon into triangles
+// by ear clipping. The result is a flat list where every three
+// points is a triangle.
+func triangulate(points []Point) []Point {
+	// drop any repeated points, including a closing point
+	// that is the same as the first.
+	poly := make([]Point, 0, len(points))
+	for _, p := range points {
+		if len(poly) == 0 || poly[len(poly)-1] != p {
+			poly = append(poly, p)
+		}
+	}
+	for len(poly) > 1 && poly[0] == poly[len(poly)-1] {
+		poly = poly[:len(poly)-1]
+	}
+	if len(poly) < 3 {
+		return nil
+	}
+
+	// work with a clockwise winding so that every
+	// convex corner has a positive cross product.
+	if signedArea(poly) < 0 {
+		for i, j := 0, len(poly)-1; i < j; i, j = i+1, j-1 {
+			poly[i], poly[j] = poly[j], poly[i]
+		}
+	}
+
+	idx := make([]int, len(poly))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	tris := make([]Point, 0, (len(poly)-2)*3)
+	for len(idx) > 3 {
+		n := len(idx)
+		clipped := false
+		for i := 0; i < n; i++ {
+			a, b, c := poly[idx[(i+n-1)%n]], poly[idx[i]], poly[idx[(i+1)%n]]
+
+			turn := cross(a, b, c)
+			if turn < 0 {
+				continue
+			}
+
+			if turn > 0 {
+				// an ear can't contain any of the other points.
+				ear := true
+				for j := 0; j < n && ear; j++ {
+					if j == i || j == (i+n-1)%n || j == (i+1)%n {
+						continue
+					}
+					p := poly[idx[j]]
+					if p != a && p != b && p != c && inTriangle(p, a, b, c) {
+						ear = false
+					}
+				}
+				if !ear {
+					continue
+				}
+				tris = append(tris, a, b, c)
+			}
+
+			// collinear points are removed without
+			// emitting a triangle.
+			idx = append(idx[:i], idx[i+1:]...)
+			clipped = true
+			break
+		}
+
+		// the polygon intersects itself, there are no
+		// ears left so fan out whatever remains.
+		if !clipped {
+			for i := 1; i < len(idx)-1; i++ {
+				tris = append(tris, poly[idx[0]], poly[idx[i]], poly[idx[i+1]])
+			}
+			return tris
+		}
+	}
+
+	tris = append(tris, poly[idx[0]], poly[idx[1]], poly[idx[2]])
+	return tris
+}
+
+// fillConvex will fill the given convex polygon with the
+// current colour. The points must wind clockwise. If
+// smooth is set the edges are anti-aliased by fading
+// the colour out over a one pixel wide border.
+func (r *Renderer) fillConvex(points []Point, smooth bool) {
+	if len(points) < 3 {
+		return
+	}
+	if !smooth {
+		tris := make([]Point, 0, (len(points)-2)*3)
+		for i := 1; i < len(points)-1; i++ {
+			tris = append(tris, points[0], points[i], points[i+1])
+		}
+		r.fillTriangles(tris)
+		return
+	}
+
+	// the inner and outer edges of the border sit half
+	// a pixel either side of the actual outline.
+	inner := make([]Point, len(points))
+	outer := make([]Point, len(points))
+	for i, p := range points {
+		prev, next := points[(i+len(points)-1)%len(points)], points[(i+1)%len(points)]
+		n0 := p.sub(prev).normalize().perpendicular().mul(-1)
+		n1 := next.sub(p).normalize().perpendicular().mul(-1)
+		normal := n0.add(n1).normalize()
+		scale := 0.5
+		if cos := normal.dot(n0); cos > 0.25 {
+			scale /= cos
+		}
+		inner[i] = p.sub(normal.mul(scale))
+		outer[i] = p.add(normal.mul(scale))
+	}
+
+	r.fillConvex(inner, false)
+
+	vertex := func(p Point, faded bool) sdl.Vertex {
+		col := r.colorAt(p)
+		if faded {
+			col.A = 0
+		}
+		return sdl.Vertex{Position: sdl.FPoint{float32(p.X), float32(p.Y)}, Color: col}
+	}
+
+	verts := make([]sdl.Vertex, 0, len(points)*6)
+	for i := range points {
+		j := (i + 1) % len(points)
+		verts = append(verts,
+			vertex(inner[i], false), vertex(outer[i], true), vertex(outer[j], true),
+			vertex(inner[i], false), vertex(outer[j], true), vertex(inner[j], false),
+		)
+	}
+	r.geometry(nil, verts)
+}
+
+// drawLines draws one pixel wide lines through the given
+// points, using the current paint if there is one. Without
+// a paint the lines stay one pixel wide on screen whatever
+// the transform is.
+func (r *Renderer) drawLines(points []sdl.FPoint) {
+	if r.clippedAway() {
+		return
+	}
+	if r.paint == nil {
+		m := r.transform
+		screen := make([]sdl.FPoint, len(points))
+		for i, p := range points {
+			x, y := m.Apply(float64(p.X), float64(p.Y))
+			screen[i] = sdl.FPoint{float32(x), float32(y)}
+		}
+		r.DrawLinesF(screen)
+		r.countDraw(nil)
+		return
+	}
+	line := make([]Point, len(points))
+	for i, p := range points {
+		line[i] = Point{float64(p.X), float64(p.Y)}
+	}
+	r.fillStroke(strokeTriangles(line, Stroke{Width: 1}, false))
+}
+
+// drawPoints draws a pixel at each of the given points, using
+// the current paint if there is one. If the transform scales or
+// rotates then the pixels are drawn as small squares.
+func (r *Renderer) drawPoints(points []sdl.Point) {
+	if r.clippedAway() {
+		return
+	}
+	if ox, oy, ok := r.offset(); ok && r.paint == nil {
+		r.DrawPoints(offsetPoints(points, int(ox), int(oy)))
+		r.countDraw(nil)
+		return
+	}
+	rects := make([]sdl.Rect, len(points))
+	for i, p := range points {
+		rects[i] = sdl.Rect{p.X, p.Y, 1, 1}
+	}
+	r.fillRects(rects)
+}
+
+// fillRects fills each of the given rectangles, using the
+// current paint if there is one.
+func (r *Renderer) fillRects(rects []sdl.Rect) {
+	if r.clippedAway() {
+		return
+	}
+	if ox, oy, ok := r.offset(); ok && r.paint == nil {
+		moved := make([]sdl.Rect, len(rects))
+		for i, rc := range rects {
+			moved[i] = sdl.Rect{rc.X + ox, rc.Y + oy, rc.W, rc.H}
+		}
+		r.FillRects(moved)
+		r.countDraw(nil)
+		return
+	}
+	tris := make([]Point, 0, len(rects)*6)
+	for _, rc := range rects {
+		x0, y0 := float64(rc.X), float64(rc.Y)
+		x1, y1 := x0+float64(rc.W), y0+float64(rc.H)
+		tris = append(tris,
+			Point{x0, y0}, Point{x1, y0}, Point{x1, y1},
+			Point{x0, y0}, Point{x1, y1}, Point{x0, y1},
+		)
+	}
+	r.fillTriangles(tris)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -1,5 +1,5 @@
 module github.com/felixangell/strife
 
-go 1.14
+go 1.16
 
 require github.com/veandco/go-sdl2 v0.4.21
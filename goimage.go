@@ -0,0 +1,113 @@
+package strife
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// NewImageFromGo creates an image from a Go image, e.g. one that was
+// decoded with the image package or generated in code. The image
+// keeps a copy of the pixels as a surface so it can be used with an
+// Atlas. It will return the image and any errors encountered.
+func NewImageFromGo(src image.Image) (*Image, error) {
+	if RenderInstance == nil {
+		return nil, fmt.Errorf("Render context has not been initialized yet.")
+	}
+
+	pixels := toNRGBA(src)
+	w, h := pixels.Rect.Dx(), pixels.Rect.Dy()
+	if w == 0 || h == 0 {
+		return nil, fmt.Errorf("Image is empty")
+	}
+
+	surface, err := sdl.CreateRGBSurfaceWithFormat(0, int32(w), int32(h), 32, uint32(sdl.PIXELFORMAT_RGBA32))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create surface of size %dx%d", w, h)
+	}
+	copyRows(surface.Pixels(), int(surface.Pitch), pixels.Pix, pixels.Stride, w*4, h)
+
+	texture, err := RenderInstance.CreateTextureFromSurface(surface)
+	if err != nil {
+		surface.Free()
+		return nil, fmt.Errorf("Failed to load image into memory")
+	}
+	RenderInstance.textureAllocated()
+
+	return newImage(texture, surface, w, h), nil
+}
+
+// ToGoImage copies the pixels of the image into a Go image. This works
+// for images with a surface, canvases and pixel images, other images
+// only exist on the GPU and can't be read back. Reading a canvas
+// back is slow so it shouldn't be done every frame.
+func (i *Image) ToGoImage() (*image.NRGBA, error) {
+	result := image.NewNRGBA(image.Rect(0, 0, i.Width, i.Height))
+
+	if i.pixels != nil {
+		copy(result.Pix, i.pixels.Pix)
+		return result, nil
+	}
+
+	if i.Surface != nil {
+		surface, err := i.Surface.ConvertFormat(uint32(sdl.PIXELFORMAT_RGBA32), 0)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to convert image: %s", err)
+		}
+		defer surface.Free()
+		copyRows(result.Pix, result.Stride, surface.Pixels(), int(surface.Pitch), i.Width*4, i.Height)
+		return result, nil
+	}
+
+	_, access, _, _, err := i.Texture.Query()
+	if err != nil || access != sdl.TEXTUREACCESS_TARGET {
+		return nil, fmt.Errorf("Image has no pixels that can be read")
+	}
+
+	r := RenderInstance
+	prev := r.GetRenderTarget()
+	if err := r.SetRenderTarget(i.Texture); err != nil {
+		return nil, err
+	}
+	err = r.ReadPixels(nil, uint32(sdl.PIXELFORMAT_RGBA32), unsafe.Pointer(&result.Pix[0]), result.Stride)
+	r.SetRenderTarget(prev)
+	r.applyClip()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read pixels: %s", err)
+	}
+
+	// the colours of a canvas are premultiplied by their alpha
+	for p := 0; p < len(result.Pix); p += 4 {
+		a := uint32(result.Pix[p+3])
+		if a == 0 || a == 255 {
+			continue
+		}
+		for c := p; c < p+3; c++ {
+			result.Pix[c] = uint8(minInt(int((uint32(result.Pix[c])*255+a/2)/a), 255))
+		}
+	}
+	return result, nil
+}
+
+// toNRGBA returns the image as a NRGBA image starting at 0, 0,
+// converting it if it isn't one already.
+func toNRGBA(src image.Image) *image.NRGBA {
+	if nrgba, ok := src.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba
+	}
+	bounds := src.Bounds()
+	result := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(result, result.Rect, src, bounds.Min, draw.Src)
+	return result
+}
+
+// copyRows copies rows of n bytes between two
+// buffers which can have different strides.
+func copyRows(dst []byte, dstStride int, src []byte, srcStride int, n, rows int) {
+	for y := 0; y < rows; y++ {
+		copy(dst[y*dstStride:y*dstStride+n], src[y*srcStride:y*srcStride+n])
+	}
+}
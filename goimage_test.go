@@ -0,0 +1,37 @@
+package strife_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/strifetest"
+)
+
+func TestCanvasToGoImage(t *testing.T) {
+	window := strifetest.NewWindow(t, 4, 4)
+	ctx := window.GetRenderContext()
+	canvas, err := strife.NewCanvas(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer canvas.Destroy()
+
+	if err := ctx.SetTarget(canvas); err != nil {
+		t.Fatal(err)
+	}
+	ctx.SetColor(&strife.Color{R: 255, G: 0, B: 0, A: 128})
+	ctx.Rect(0, 0, 2, 4, strife.Fill)
+	ctx.ResetTarget()
+
+	pixels, err := canvas.ToGoImage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pixels.NRGBAAt(0, 0), (color.NRGBA{255, 0, 0, 128}); got != want {
+		t.Errorf("translucent pixel is %v, want %v", got, want)
+	}
+	if got := pixels.NRGBAAt(3, 0); got != (color.NRGBA{}) {
+		t.Errorf("empty pixel is %v, want transparent", got)
+	}
+}
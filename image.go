@@ -2,6 +2,10 @@ package strife
 
 import (
 	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"runtime"
 
 	img "github.com/veandco/go-sdl2/img"
 	"github.com/veandco/go-sdl2/sdl"
@@ -12,6 +16,33 @@ type Image struct {
 	*sdl.Texture
 	*sdl.Surface
 	Width, Height int
+
+	blend    BlendMode
+	hasBlend bool
+	tint     *Color
+
+	// fade is how much the alpha is turned down, it is
+	// stored this way round so the zero value is opaque.
+	fade uint8
+
+	// pixels is a copy of the pixels
+	// of a PixelImage.
+	pixels *image.NRGBA
+
+	// premultiplied is set for canvases, whose colours are
+	// already multiplied by their alpha when they are drawn.
+	premultiplied bool
+}
+
+// newImage wraps the given texture and surface as an image
+// of the given size, the surface can be nil.
+func newImage(texture *sdl.Texture, surface *sdl.Surface, w, h int) *Image {
+	return &Image{
+		Texture: texture,
+		Surface: surface,
+		Width:   w,
+		Height:  h,
+	}
 }
 
 // LoadImage will load the image at the given path. It will
@@ -22,22 +53,64 @@ func LoadImage(path string) (*Image, error) {
 		return nil, fmt.Errorf("Failed to load image '%s'\n", path)
 	}
 
+	return imageFromSurface(surface, path)
+}
+
+// LoadImageFS will load the image with the given name from the
+// file system, e.g. an embed.FS. It will return the loaded
+// image, and any errors encountered.
+func LoadImageFS(fsys fs.FS, name string) (*Image, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load image '%s'\n", name)
+	}
+	return loadImageBytes(data, name)
+}
+
+// LoadImageReader will load an image from the given reader,
+// in any format LoadImage supports. It will return the loaded
+// image, and any errors encountered.
+func LoadImageReader(r io.Reader) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read image: %s", err)
+	}
+	return loadImageBytes(data, "reader")
+}
+
+// loadImageBytes loads an image from the encoded image in memory,
+// the name is only used for errors.
+func loadImageBytes(data []byte, name string) (*Image, error) {
+	rw, err := sdl.RWFromMem(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load image '%s'\n", name)
+	}
+	surface, err := img.LoadRW(rw, true)
+	// SDL reads from the slice while decoding, so it
+	// mustn't be collected before LoadRW is done.
+	runtime.KeepAlive(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load image '%s'\n", name)
+	}
+	return imageFromSurface(surface, name)
+}
+
+// imageFromSurface creates an image that owns the given surface,
+// which is freed if the image can't be created.
+func imageFromSurface(surface *sdl.Surface, name string) (*Image, error) {
 	if RenderInstance == nil {
+		surface.Free()
 		return nil, fmt.Errorf("Render context has not been initialized yet.")
 	}
 
 	texture, err := RenderInstance.CreateTextureFromSurface(surface)
 	if err != nil {
 		surface.Free()
-		return nil, fmt.Errorf("Failed to load '%s' into memory\n", path)
+		return nil, fmt.Errorf("Failed to load '%s' into memory\n", name)
 	}
+	RenderInstance.textureAllocated()
 
-	image := &Image{
-		texture,
-		surface,
-		int(surface.W),
-		int(surface.H),
-	}
+	image := newImage(texture, surface, int(surface.W), int(surface.H))
 	return image, nil
 }
 
@@ -50,5 +123,7 @@ func (i *Image) GetSurface() *sdl.Surface {
 // resource.
 func (i *Image) Destroy() {
 	i.Texture.Destroy()
-	i.Surface.Free()
+	if i.Surface != nil {
+		i.Surface.Free()
+	}
 }
@@ -0,0 +1,299 @@
+package strife
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JPEGQuality is the quality, from 1 to 100,
+// that images are saved as JPEGs with.
+const JPEGQuality = 90
+
+// ResizeFilter is how the pixels of an
+// image are picked when it is resized.
+type ResizeFilter int
+
+// Types of resize filter, ResizeNearest picks the closest
+// pixel which keeps pixel art sharp; and ResizeBilinear
+// blends the closest pixels which is smoother. When shrinking
+// an image ResizeBilinear averages all of the pixels that
+// each new pixel covers, so small thumbnails don't shimmer.
+const (
+	ResizeNearest ResizeFilter = iota
+	ResizeBilinear
+)
+
+// Save saves the image at the given path, as a PNG or a JPEG
+// depending on if the path ends in .png, .jpg or .jpeg. JPEGs
+// have no alpha channel, see ToGoImage for the images that
+// can be saved.
+func (i *Image) Save(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".png" && ext != ".jpg" && ext != ".jpeg" {
+		return fmt.Errorf("Can't save image as '%s', only .png, .jpg and .jpeg are supported", ext)
+	}
+
+	pixels, err := i.ToGoImage()
+	if err != nil {
+		return err
+	}
+
+	// the image is encoded first so a failure
+	// doesn't leave half of a file behind.
+	var buf bytes.Buffer
+	if ext == ".png" {
+		err = png.Encode(&buf, pixels)
+	} else {
+		err = jpeg.Encode(&buf, pixels, &jpeg.Options{Quality: JPEGQuality})
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to save image '%s': %s", path, err)
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+// writeFile writes the data to a temporary file next to the given
+// path and then moves it over the path, so if writing fails the
+// file that was there before is left as it was.
+func writeFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("Failed to write '%s': %s", path, err)
+	}
+	temp := file.Name()
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(temp, 0644)
+	}
+	if err == nil {
+		err = os.Rename(temp, path)
+	}
+	if err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("Failed to write '%s': %s", path, err)
+	}
+	return nil
+}
+
+// Crop returns a new image of the given area of the image,
+// the area is cut down to fit inside of the image.
+func (i *Image) Crop(area Rectangle) (*Image, error) {
+	src, err := i.ToGoImage()
+	if err != nil {
+		return nil, err
+	}
+	cropped, err := cropPixels(src, area)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageFromGo(cropped)
+}
+
+// Resize returns a new image of the image scaled to the given size
+func (i *Image) Resize(w, h int, filter ResizeFilter) (*Image, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("Image size must be positive, not %dx%d", w, h)
+	}
+	src, err := i.ToGoImage()
+	if err != nil {
+		return nil, err
+	}
+	return NewImageFromGo(resizePixels(src, w, h, filter))
+}
+
+// FlipH returns a new image of the image mirrored left to right
+func (i *Image) FlipH() (*Image, error) {
+	return i.transformPixels(flipPixelsH)
+}
+
+// FlipV returns a new image of the image mirrored top to bottom
+func (i *Image) FlipV() (*Image, error) {
+	return i.transformPixels(flipPixelsV)
+}
+
+// Rotate90 returns a new image of the image turned
+// 90 degrees clockwise, swapping its width and height.
+func (i *Image) Rotate90() (*Image, error) {
+	return i.transformPixels(rotatePixels90)
+}
+
+// ColorKey returns a new image of the image where every pixel
+// of the given colour is made transparent. Only the red, green
+// and blue of the colour are compared.
+func (i *Image) ColorKey(key *Color) (*Image, error) {
+	if key == nil {
+		return nil, fmt.Errorf("No colour key was given")
+	}
+	return i.transformPixels(func(src *image.NRGBA) *image.NRGBA {
+		return colorKeyPixels(src, *key)
+	})
+}
+
+// transformPixels creates an image from the pixels of
+// this image after they have been passed through fn.
+func (i *Image) transformPixels(fn func(*image.NRGBA) *image.NRGBA) (*Image, error) {
+	src, err := i.ToGoImage()
+	if err != nil {
+		return nil, err
+	}
+	return NewImageFromGo(fn(src))
+}
+
+// cropPixels copies the area of the image that is inside of
+// the given area, it is an error if they don't overlap.
+func cropPixels(src *image.NRGBA, area Rectangle) (*image.NRGBA, error) {
+	bounds := image.Rect(area.X, area.Y, area.X+area.W, area.Y+area.H).Intersect(src.Rect)
+	if bounds.Empty() {
+		return nil, fmt.Errorf("Crop area is outside of the image")
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	copyRows(dst.Pix, dst.Stride, src.Pix[src.PixOffset(bounds.Min.X, bounds.Min.Y):], src.Stride, bounds.Dx()*4, bounds.Dy())
+	return dst, nil
+}
+
+// remapPixels creates an image of the given size where each pixel
+// is copied from the pixel of the source that from returns.
+func remapPixels(src *image.NRGBA, w, h int, from func(x, y int) (int, int)) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := from(x, y)
+			s, d := src.PixOffset(sx, sy), dst.PixOffset(x, y)
+			copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+		}
+	}
+	return dst
+}
+
+func flipPixelsH(src *image.NRGBA) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	return remapPixels(src, w, h, func(x, y int) (int, int) {
+		return w - 1 - x, y
+	})
+}
+
+func flipPixelsV(src *image.NRGBA) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	return remapPixels(src, w, h, func(x, y int) (int, int) {
+		return x, h - 1 - y
+	})
+}
+
+func rotatePixels90(src *image.NRGBA) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	return remapPixels(src, h, w, func(x, y int) (int, int) {
+		return y, h - 1 - x
+	})
+}
+
+func colorKeyPixels(src *image.NRGBA, key Color) *image.NRGBA {
+	dst := image.NewNRGBA(src.Rect)
+	copy(dst.Pix, src.Pix)
+	for p := 0; p < len(dst.Pix); p += 4 {
+		if dst.Pix[p] == key.R && dst.Pix[p+1] == key.G && dst.Pix[p+2] == key.B {
+			dst.Pix[p], dst.Pix[p+1], dst.Pix[p+2], dst.Pix[p+3] = 0, 0, 0, 0
+		}
+	}
+	return dst
+}
+
+// resizePixels scales the image to the given size
+func resizePixels(src *image.NRGBA, w, h int, filter ResizeFilter) *image.NRGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	if filter == ResizeNearest {
+		sx, sy := float64(sw)/float64(w), float64(sh)/float64(h)
+		return remapPixels(src, w, h, func(x, y int) (int, int) {
+			return int(float64(x) * sx), int(float64(y) * sy)
+		})
+	}
+
+	// the image is scaled across and then down. The colours are
+	// weighted by their alpha so that transparent pixels don't
+	// bleed their colour into the edges.
+	across := resizeWeights(sw, w)
+	down := resizeWeights(sh, h)
+
+	rows := make([]float64, w*sh*4)
+	for y := 0; y < sh; y++ {
+		for x, taps := range across {
+			out := rows[(y*w+x)*4:]
+			for _, tap := range taps {
+				p := src.Pix[src.PixOffset(tap.index, y):]
+				a := float64(p[3]) * tap.weight
+				out[0] += float64(p[0]) * a
+				out[1] += float64(p[1]) * a
+				out[2] += float64(p[2]) * a
+				out[3] += a
+			}
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y, taps := range down {
+		for x := 0; x < w; x++ {
+			var sum [4]float64
+			for _, tap := range taps {
+				in := rows[(tap.index*w+x)*4:]
+				for c := range sum {
+					sum[c] += in[c] * tap.weight
+				}
+			}
+
+			d := dst.Pix[dst.PixOffset(x, y):]
+			d[3] = uint8(math.Round(math.Min(sum[3], 255)))
+			if sum[3] > 0 {
+				for c := 0; c < 3; c++ {
+					d[c] = uint8(math.Round(math.Min(sum[c]/sum[3], 255)))
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// resizeTap is how much a source pixel adds to a resized pixel
+type resizeTap struct {
+	index  int
+	weight float64
+}
+
+// resizeWeights works out which source pixels make up each pixel
+// when resizing from n pixels to size pixels. When shrinking each
+// pixel is the average of the source pixels it covers, and when
+// growing it is blended between the two closest source pixels.
+func resizeWeights(n, size int) [][]resizeTap {
+	scale := float64(n) / float64(size)
+	weights := make([][]resizeTap, size)
+	for d := range weights {
+		if scale > 1 {
+			lo, hi := float64(d)*scale, float64(d+1)*scale
+			for i := int(lo); i < n && float64(i) < hi; i++ {
+				cover := math.Min(hi, float64(i+1)) - math.Max(lo, float64(i))
+				if cover > 0 {
+					weights[d] = append(weights[d], resizeTap{i, cover / scale})
+				}
+			}
+			continue
+		}
+
+		centre := (float64(d)+0.5)*scale - 0.5
+		i := math.Floor(centre)
+		f := centre - i
+		weights[d] = []resizeTap{
+			{clampInt(int(i), 0, n-1), 1 - f},
+			{clampInt(int(i)+1, 0, n-1), f},
+		}
+	}
+	return weights
+}
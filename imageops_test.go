@@ -0,0 +1,189 @@
+package strife
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPixels creates a w by h image where each pixel
+// is given its own colour so it can be told apart.
+func testPixels(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), uint8(x*16 + y), 255})
+		}
+	}
+	return img
+}
+
+func checkPixels(t *testing.T, got *image.NRGBA, w, h int, want func(x, y int) color.NRGBA) {
+	t.Helper()
+	if got.Rect.Dx() != w || got.Rect.Dy() != h {
+		t.Fatalf("got a %dx%d image, want %dx%d", got.Rect.Dx(), got.Rect.Dy(), w, h)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if c, wc := got.NRGBAAt(x, y), want(x, y); c != wc {
+				t.Errorf("pixel (%d, %d) is %v, want %v", x, y, c, wc)
+			}
+		}
+	}
+}
+
+func TestCropPixels(t *testing.T) {
+	src := testPixels(4, 3)
+	tests := []struct {
+		name       string
+		area       Rectangle
+		x, y, w, h int
+	}{
+		{"inside", Rectangle{1, 1, 2, 2}, 1, 1, 2, 2},
+		{"whole", Rectangle{0, 0, 4, 3}, 0, 0, 4, 3},
+		{"overhangs", Rectangle{2, -1, 5, 3}, 2, 0, 2, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := cropPixels(src, test.area)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkPixels(t, got, test.w, test.h, func(x, y int) color.NRGBA {
+				return src.NRGBAAt(test.x+x, test.y+y)
+			})
+		})
+	}
+
+	if _, err := cropPixels(src, Rectangle{4, 0, 2, 2}); err == nil {
+		t.Error("expected an error cropping outside of the image")
+	}
+}
+
+func TestFlipAndRotatePixels(t *testing.T) {
+	src := testPixels(3, 2)
+	tests := []struct {
+		name string
+		fn   func(*image.NRGBA) *image.NRGBA
+		w, h int
+		from func(x, y int) (int, int)
+	}{
+		{"flip h", flipPixelsH, 3, 2, func(x, y int) (int, int) { return 2 - x, y }},
+		{"flip v", flipPixelsV, 3, 2, func(x, y int) (int, int) { return x, 1 - y }},
+		// the left column of the source becomes the top row
+		{"rotate 90", rotatePixels90, 2, 3, func(x, y int) (int, int) { return y, 1 - x }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkPixels(t, test.fn(src), test.w, test.h, func(x, y int) color.NRGBA {
+				return src.NRGBAAt(test.from(x, y))
+			})
+		})
+	}
+}
+
+func TestColorKeyPixels(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{255, 0, 255, 255})
+	src.SetNRGBA(1, 0, color.NRGBA{255, 0, 254, 255})
+	src.SetNRGBA(2, 0, color.NRGBA{255, 0, 255, 100})
+
+	// the alpha of the key isn't compared
+	got := colorKeyPixels(src, Color{255, 0, 255, 0})
+	want := []color.NRGBA{{}, {255, 0, 254, 255}, {}}
+	checkPixels(t, got, 3, 1, func(x, y int) color.NRGBA {
+		return want[x]
+	})
+	if src.NRGBAAt(0, 0).A != 255 {
+		t.Error("colour keying changed the source image")
+	}
+}
+
+func TestResizePixels(t *testing.T) {
+	src := testPixels(2, 2)
+	tests := []struct {
+		name   string
+		filter ResizeFilter
+		w, h   int
+		want   func(x, y int) color.NRGBA
+	}{
+		{"nearest grows", ResizeNearest, 4, 4, func(x, y int) color.NRGBA {
+			return src.NRGBAAt(x/2, y/2)
+		}},
+		{"nearest shrinks", ResizeNearest, 1, 1, func(x, y int) color.NRGBA {
+			return src.NRGBAAt(0, 0)
+		}},
+		{"bilinear same size", ResizeBilinear, 2, 2, src.NRGBAAt},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkPixels(t, resizePixels(src, test.w, test.h, test.filter), test.w, test.h, test.want)
+		})
+	}
+}
+
+func TestResizeBilinearAverages(t *testing.T) {
+	// a checkerboard of black and white pixels averages
+	// out to grey however far it is shrunk.
+	src := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			v := uint8(255 * ((x + y) % 2))
+			src.SetNRGBA(x, y, color.NRGBA{v, v, v, 255})
+		}
+	}
+	for _, size := range []int{1, 2, 3} {
+		got := resizePixels(src, size, size, ResizeBilinear)
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				if c := got.NRGBAAt(x, y); c.A != 255 || c.R < 120 || c.R > 135 {
+					t.Errorf("%dx%d: pixel (%d, %d) is %v, want grey", size, size, x, y, c)
+				}
+			}
+		}
+	}
+}
+
+func TestResizeBilinearTransparency(t *testing.T) {
+	// the colour of a transparent pixel must not
+	// bleed into the pixel it is averaged with.
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{255, 0, 0, 255})
+	src.SetNRGBA(1, 0, color.NRGBA{0, 0, 255, 0})
+
+	got := resizePixels(src, 1, 1, ResizeBilinear).NRGBAAt(0, 0)
+	if want := (color.NRGBA{255, 0, 0, 128}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shot.png")
+	for _, data := range []string{"first", "second"} {
+		if err := writeFile(path, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := os.ReadFile(path); string(got) != data {
+			t.Errorf("file contains %q, want %q", got, data)
+		}
+	}
+
+	// a directory can't be replaced by a file, so the write
+	// fails and must leave the directory and nothing else.
+	blocked := filepath.Join(dir, "blocked")
+	if err := os.MkdirAll(filepath.Join(blocked, "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFile(blocked, []byte("data")); err == nil {
+		t.Error("expected an error writing over a directory")
+	}
+	if _, err := os.Stat(filepath.Join(blocked, "keep")); err != nil {
+		t.Errorf("failed write removed what was there: %s", err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 2 {
+		t.Errorf("got %d files after a failed write, want 2", len(files))
+	}
+}
@@ -0,0 +1,141 @@
+package strife
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// LogicalScale is how a logical resolution is
+// scaled up to fill the window.
+type LogicalScale int
+
+// Types of logical scaling, ScaleFit scales to fill as much
+// of the window as it can with sharp pixels; ScaleSmooth is
+// the same but blends the pixels as they are scaled; and
+// ScaleInteger only scales by whole numbers so every pixel
+// is the same size, which is best for pixel art. Any space
+// left over is filled with black bars.
+const (
+	ScaleFit LogicalScale = iota
+	ScaleSmooth
+	ScaleInteger
+)
+
+// SetLogicalResolution makes the renderer draw at the given resolution
+// whatever the size of the window is. Everything is drawn into an
+// off-screen canvas of that size which is scaled up to fit the
+// window when Display is called, with black bars around it to keep
+// its shape. GetSize returns the logical size, and mouse positions
+// are reported in logical co-ordinates.
+func (r *Renderer) SetLogicalResolution(w, h int, scale LogicalScale) error {
+	if w <= 0 || h <= 0 {
+		return fmt.Errorf("Logical size must be positive, not %dx%d", w, h)
+	}
+
+	// the scale quality of a texture is decided
+	// when the texture is created.
+	quality := "0"
+	if scale == ScaleSmooth {
+		quality = "1"
+	}
+	prevQuality := sdl.GetHint(sdl.HINT_RENDER_SCALE_QUALITY)
+	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, quality)
+	canvas, err := NewCanvas(w, h)
+	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, prevQuality)
+	if err != nil {
+		return err
+	}
+
+	r.ClearLogicalResolution()
+	r.logical = canvas
+	r.logicalScale = scale
+	if r.target == nil {
+		r.ResetTarget()
+	}
+	return nil
+}
+
+// ClearLogicalResolution goes back to drawing straight to the window
+func (r *Renderer) ClearLogicalResolution() {
+	if r.logical == nil {
+		return
+	}
+	r.logical.Destroy()
+	r.logical = nil
+	if r.target == nil {
+		r.ResetTarget()
+	}
+}
+
+// GetLogicalResolution returns the logical resolution, if no logical
+// resolution is set then false is returned.
+func (r *Renderer) GetLogicalResolution() (int, int, bool) {
+	if r.logical == nil {
+		return 0, 0, false
+	}
+	return r.logical.Width, r.logical.Height, true
+}
+
+// logicalViewport returns where the logical canvas is
+// drawn in the window, in pixels.
+func (r *Renderer) logicalViewport() *sdl.Rect {
+	ow, oh, err := r.Renderer.GetOutputSize()
+	if err != nil {
+		return &sdl.Rect{0, 0, 0, 0}
+	}
+	lw, lh := float64(r.logical.Width), float64(r.logical.Height)
+
+	scale := math.Min(float64(ow)/lw, float64(oh)/lh)
+	if r.logicalScale == ScaleInteger {
+		scale = math.Max(math.Floor(scale), 1)
+	}
+
+	w, h := int32(math.Round(lw*scale)), int32(math.Round(lh*scale))
+	return &sdl.Rect{(ow - w) / 2, (oh - h) / 2, w, h}
+}
+
+// presentLogical draws the logical canvas to the window
+// ready for it to be displayed.
+func (r *Renderer) presentLogical() {
+	r.SetRenderTarget(nil)
+	r.SetClipRect(nil)
+	r.SetDrawColor(0, 0, 0, 255)
+	r.Renderer.Clear()
+
+	texture := r.logical.Texture
+	texture.SetBlendMode(sdl.BLENDMODE_NONE)
+	texture.SetColorMod(255, 255, 255)
+	texture.SetAlphaMod(255)
+	r.Copy(texture, nil, r.logicalViewport())
+	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+}
+
+// ToLogical maps a position in the window, such as the mouse
+// position from SDL, into logical co-ordinates. If there is
+// no logical size then the position is returned as is.
+func (w *RenderWindow) ToLogical(x, y int) (int, int) {
+	r := w.renderContext
+	if r == nil || r.logical == nil {
+		return x, y
+	}
+
+	// the window size can be different from the output
+	// size on high DPI displays.
+	ww, wh := w.Window.GetSize()
+	ow, oh, err := r.Renderer.GetOutputSize()
+	if err != nil || ww == 0 || wh == 0 {
+		return x, y
+	}
+	px := float64(x) * float64(ow) / float64(ww)
+	py := float64(y) * float64(oh) / float64(wh)
+
+	view := r.logicalViewport()
+	if view.W == 0 || view.H == 0 {
+		return x, y
+	}
+	lx := (px - float64(view.X)) * float64(r.logical.Width) / float64(view.W)
+	ly := (py - float64(view.Y)) * float64(r.logical.Height) / float64(view.H)
+	return int(math.Floor(lx)), int(math.Floor(ly))
+}
@@ -0,0 +1,110 @@
+package strife
+
+// SliceMode is how the edges and centre of a
+// nine slice are filled when it is resized.
+type SliceMode int
+
+// Types of slice modes, Stretch scales the edges and
+// centre to fit, and Tile repeats them at their native size.
+const (
+	Stretch SliceMode = iota
+	Tile
+)
+
+// NineSlice is an image that is cut into nine pieces by the
+// four insets so that it can be drawn at any size. The corners
+// are always drawn at their native size, the edges and centre
+// are stretched or tiled to fill the rest, see SliceMode.
+type NineSlice struct {
+	Image                    *Image
+	Left, Top, Right, Bottom int
+	Mode                     SliceMode
+}
+
+// NewNineSlice creates a nine slice from the given image, the insets
+// are how far in from each side of the image the cuts are.
+func NewNineSlice(image *Image, left, top, right, bottom int) *NineSlice {
+	return &NineSlice{
+		Image:  image,
+		Left:   left,
+		Top:    top,
+		Right:  right,
+		Bottom: bottom,
+	}
+}
+
+// NineSlice will render the given nine slice at the given x, y
+// co-ordinates stretched to the given size. If the size is smaller
+// than the corners then the corners are scaled down to fit.
+func (r *Renderer) NineSlice(ns *NineSlice, x, y, w, h int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	iw, ih := ns.Image.Width, ns.Image.Height
+	left, right := fitInsets(ns.Left, ns.Right, w)
+	top, bottom := fitInsets(ns.Top, ns.Bottom, h)
+
+	// the columns and rows of the source image and the
+	// destination, as offset and size pairs.
+	srcCols := [3][2]int{{0, ns.Left}, {ns.Left, iw - ns.Left - ns.Right}, {iw - ns.Right, ns.Right}}
+	srcRows := [3][2]int{{0, ns.Top}, {ns.Top, ih - ns.Top - ns.Bottom}, {ih - ns.Bottom, ns.Bottom}}
+	dstCols := [3][2]int{{x, left}, {x + left, w - left - right}, {x + w - right, right}}
+	dstRows := [3][2]int{{y, top}, {y + top, h - top - bottom}, {y + h - bottom, bottom}}
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			src := Rectangle{srcCols[col][0], srcRows[row][0], srcCols[col][1], srcRows[row][1]}
+			dst := Rectangle{dstCols[col][0], dstRows[row][0], dstCols[col][1], dstRows[row][1]}
+			if src.W <= 0 || src.H <= 0 || dst.W <= 0 || dst.H <= 0 {
+				continue
+			}
+
+			corner := row != 1 && col != 1
+			if ns.Mode == Tile && !corner {
+				r.tileImage(ns.Image, src, dst, col == 1, row == 1)
+				continue
+			}
+			r.SubImageScale(ns.Image, dst.X, dst.Y, src.X, src.Y, src.W, src.H, dst.W, dst.H)
+		}
+	}
+}
+
+// fitInsets scales the two insets down so
+// that they fit into the given size.
+func fitInsets(a, b, size int) (int, int) {
+	if a+b <= size || a+b == 0 {
+		return a, b
+	}
+	a = a * size / (a + b)
+	return a, size - a
+}
+
+// tileImage fills dst by repeating the src part of the image.
+// It only repeats along the axes that are set, along the others
+// the image is stretched to fit.
+func (r *Renderer) tileImage(image *Image, src, dst Rectangle, tileX, tileY bool) {
+	stepX, stepY := dst.W, dst.H
+	if tileX {
+		stepX = src.W
+	}
+	if tileY {
+		stepY = src.H
+	}
+
+	for ty := 0; ty < dst.H; ty += stepY {
+		th := minInt(stepY, dst.H-ty)
+		sh := src.H
+		if tileY {
+			sh = th
+		}
+		for tx := 0; tx < dst.W; tx += stepX {
+			tw := minInt(stepX, dst.W-tx)
+			sw := src.W
+			if tileX {
+				sw = tw
+			}
+			r.SubImageScale(image, dst.X+tx, dst.Y+ty, src.X, src.Y, sw, sh, tw, th)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package strife
+
+import (
+	"math"
+	"sort"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ColorStop is a colour at a position along a gradient,
+// the Offset runs from 0 at the start of the gradient
+// to 1 at the end of it.
+type ColorStop struct {
+	Offset float64
+	Color  *Color
+}
+
+// Paint is used to colour shapes and text in place of
+// the current colour, see LinearGradient and RadialGradient.
+// Paints are set with Renderer.SetPaint.
+type Paint interface {
+	// colorAt returns the colour of the paint at the given point.
+	colorAt(p Point) sdl.Color
+
+	// shade colours the given list of triangles, splitting
+	// them up if needed. uvs are the texture co-ordinates
+	// of each point and can be nil.
+	shade(tris, uvs []Point) []sdl.Vertex
+}
+
+// LinearGradient blends between its colour stops along the line
+// from X0, Y0 to X1, Y1. Points before the start or past the
+// end of the line take the colour of the first or last stop.
+type LinearGradient struct {
+	X0, Y0, X1, Y1 float64
+	Stops          []ColorStop
+}
+
+// NewLinearGradient creates a linear gradient along the line
+// from x0, y0 to x1, y1 with the given colour stops.
+func NewLinearGradient(x0, y0, x1, y1 float64, stops ...ColorStop) *LinearGradient {
+	return &LinearGradient{x0, y0, x1, y1, sortStops(stops)}
+}
+
+// RadialGradient blends between its colour stops outwards
+// from the centre X, Y to the given Radius.
+type RadialGradient struct {
+	X, Y, Radius float64
+	Stops        []ColorStop
+}
+
+// NewRadialGradient creates a radial gradient centred on x, y
+// of the given radius with the given colour stops.
+func NewRadialGradient(x, y, radius float64, stops ...ColorStop) *RadialGradient {
+	return &RadialGradient{x, y, radius, sortStops(stops)}
+}
+
+func sortStops(stops []ColorStop) []ColorStop {
+	sorted := append([]ColorStop{}, stops...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+	return sorted
+}
+
+// gradientColor returns the colour at offset t along the
+// given colour stops, which must be in order.
+func gradientColor(stops []ColorStop, t float64) sdl.Color {
+	if len(stops) == 0 {
+		return White.ToSDLColor()
+	}
+	if t <= stops[0].Offset {
+		return stops[0].Color.ToSDLColor()
+	}
+
+	for i := 1; i < len(stops); i++ {
+		a, b := stops[i-1], stops[i]
+		if t > b.Offset {
+			continue
+		}
+		f := 0.0
+		if b.Offset > a.Offset {
+			f = (t - a.Offset) / (b.Offset - a.Offset)
+		}
+		mix := func(x, y uint8) uint8 {
+			return uint8(math.Round(float64(x) + (float64(y)-float64(x))*f))
+		}
+		return sdl.Color{
+			R: mix(a.Color.R, b.Color.R),
+			G: mix(a.Color.G, b.Color.G),
+			B: mix(a.Color.B, b.Color.B),
+			A: mix(a.Color.A, b.Color.A),
+		}
+	}
+	return stops[len(stops)-1].Color.ToSDLColor()
+}
+
+// offset returns how far along the gradient the given point is.
+func (g *LinearGradient) offset(p Point) float64 {
+	dx, dy := g.X1-g.X0, g.Y1-g.Y0
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return 0
+	}
+	return ((p.X-g.X0)*dx + (p.Y-g.Y0)*dy) / length
+}
+
+func (g *LinearGradient) colorAt(p Point) sdl.Color {
+	return gradientColor(g.Stops, g.offset(p))
+}
+
+// shade cuts each triangle along the lines where the colour stops
+// are. The colour only changes linearly between two stops, so
+// each piece can be coloured exactly by its vertices.
+func (g *LinearGradient) shade(tris, uvs []Point) []sdl.Vertex {
+	var verts []sdl.Vertex
+	for i := 0; i+2 < len(tris); i += 3 {
+		poly := make([]shadePoint, 3)
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for j := range poly {
+			poly[j] = shadePoint{pos: tris[i+j]}
+			if uvs != nil {
+				poly[j].uv = uvs[i+j]
+			}
+			poly[j].t = g.offset(poly[j].pos)
+			lo, hi = math.Min(lo, poly[j].t), math.Max(hi, poly[j].t)
+		}
+
+		for _, stop := range g.Stops {
+			if stop.Offset <= lo || stop.Offset >= hi {
+				continue
+			}
+			var below []shadePoint
+			below, poly = splitPolygon(poly, stop.Offset)
+			verts = g.appendFan(verts, below)
+		}
+		verts = g.appendFan(verts, poly)
+	}
+	return verts
+}
+
+func (g *LinearGradient) appendFan(verts []sdl.Vertex, poly []shadePoint) []sdl.Vertex {
+	for i := 1; i+1 < len(poly); i++ {
+		for _, p := range []shadePoint{poly[0], poly[i], poly[i+1]} {
+			verts = append(verts, p.vertex(gradientColor(g.Stops, p.t)))
+		}
+	}
+	return verts
+}
+
+func (g *RadialGradient) offset(p Point) float64 {
+	if g.Radius <= 0 {
+		return 1
+	}
+	return math.Hypot(p.X-g.X, p.Y-g.Y) / g.Radius
+}
+
+func (g *RadialGradient) colorAt(p Point) sdl.Color {
+	return gradientColor(g.Stops, g.offset(p))
+}
+
+// shade will subdivide the triangles until they are small
+// enough that the colour can be blended across them. Triangles
+// are split across their longest edge, so long thin triangles,
+// e.g. from a one pixel high rectangle, are only split along
+// their length rather than into thousands of slivers.
+func (g *RadialGradient) shade(tris, uvs []Point) []sdl.Vertex {
+	maxEdge := math.Max(g.Radius/16, 2)
+
+	var verts []sdl.Vertex
+	var split func(a, b, c shadePoint, depth int)
+	split = func(a, b, c shadePoint, depth int) {
+		ab, bc, ca := a.pos.distance(b.pos), b.pos.distance(c.pos), c.pos.distance(a.pos)
+		longest := math.Max(ab, math.Max(bc, ca))
+		area := math.Abs((b.pos.X-a.pos.X)*(c.pos.Y-a.pos.Y)-(c.pos.X-a.pos.X)*(b.pos.Y-a.pos.Y)) / 2
+		if longest <= maxEdge || area < 1 || depth >= 12 {
+			for _, p := range []shadePoint{a, b, c} {
+				verts = append(verts, p.vertex(g.colorAt(p.pos)))
+			}
+			return
+		}
+
+		// rotate the triangle so the longest edge is a to b
+		switch longest {
+		case bc:
+			a, b, c = b, c, a
+		case ca:
+			a, b, c = c, a, b
+		}
+		mid := a.lerp(b, 0.5)
+		split(a, mid, c, depth+1)
+		split(mid, b, c, depth+1)
+	}
+
+	for i := 0; i+2 < len(tris); i += 3 {
+		var p [3]shadePoint
+		for j := range p {
+			p[j] = shadePoint{pos: tris[i+j]}
+			if uvs != nil {
+				p[j].uv = uvs[i+j]
+			}
+		}
+		split(p[0], p[1], p[2], 0)
+	}
+	return verts
+}
+
+// shadePoint is a vertex that is being shaded, t is
+// how far along the gradient the vertex is.
+type shadePoint struct {
+	pos, uv Point
+	t       float64
+}
+
+func (a shadePoint) lerp(b shadePoint, f float64) shadePoint {
+	return shadePoint{
+		pos: a.pos.add(b.pos.sub(a.pos).mul(f)),
+		uv:  a.uv.add(b.uv.sub(a.uv).mul(f)),
+		t:   a.t + (b.t-a.t)*f,
+	}
+}
+
+func (a shadePoint) vertex(col sdl.Color) sdl.Vertex {
+	return sdl.Vertex{
+		Position: sdl.FPoint{float32(a.pos.X), float32(a.pos.Y)},
+		Color:    col,
+		TexCoord: sdl.FPoint{float32(a.uv.X), float32(a.uv.Y)},
+	}
+}
+
+// splitPolygon cuts the convex polygon into the part
+// where t is below the cut and the part where it is above.
+func splitPolygon(poly []shadePoint, cut float64) (below, above []shadePoint) {
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if a.t <= cut {
+			below = append(below, a)
+		}
+		if a.t >= cut {
+			above = append(above, a)
+		}
+		if (a.t < cut && b.t > cut) || (a.t > cut && b.t < cut) {
+			p := a.lerp(b, (cut-a.t)/(b.t-a.t))
+			p.t = cut
+			below = append(below, p)
+			above = append(above, p)
+		}
+	}
+	return below, above
+}
+
+// SetPaint will set a paint such as a gradient to draw
+// shapes and text with instead of the current colour.
+// The paint is cleared by SetPaint(nil) or by SetColor.
+func (r *Renderer) SetPaint(paint Paint) {
+	r.paint = paint
+}
+
+// GetPaint returns the current paint, or nil if
+// drawing with a solid colour.
+func (r *Renderer) GetPaint() Paint {
+	return r.paint
+}
+
+// colorAt returns the colour that would be drawn
+// at the given point.
+func (r *Renderer) colorAt(p Point) sdl.Color {
+	if r.paint != nil {
+		return r.paint.colorAt(p)
+	}
+	return r.color.ToSDLColor()
+}
+
+// paintTexture draws the given texture into the given
+// rectangle with the current paint blended over it.
+func (r *Renderer) paintTexture(texture *sdl.Texture, x, y, w, h float64) {
+	tl, tr := Point{x, y}, Point{x + w, y}
+	bl, br := Point{x, y + h}, Point{x + w, y + h}
+	tris := []Point{tl, tr, br, tl, br, bl}
+	uvs := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 0}, {1, 1}, {0, 1}}
+
+	// the tint and alpha are applied to the vertices
+	// so the texture is left as it is.
+	setTextureBlend(texture, r.blend)
+	texture.SetColorMod(255, 255, 255)
+	texture.SetAlphaMod(255)
+	r.geometry(texture, r.paint.shade(tris, uvs))
+}
+
+// paintOpaque checks if every colour of the paint is opaque
+func paintOpaque(paint Paint) bool {
+	var stops []ColorStop
+	switch p := paint.(type) {
+	case *LinearGradient:
+		stops = p.Stops
+	case *RadialGradient:
+		stops = p.Stops
+	default:
+		return false
+	}
+	for _, stop := range stops {
+		if stop.Color.A != 255 {
+			return false
+		}
+	}
+	return true
+}
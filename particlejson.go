@@ -0,0 +1,238 @@
+package strife
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// particleJSON is the JSON definition of a particle emitter, e.g.
+//
+//	{
+//		"rate": 40,
+//		"lifetime": [0.5, 1.5],
+//		"speed": [60, 120],
+//		"angle": [250, 290],
+//		"gravity": [0, 200],
+//		"colors": [[0, "#ffcc00"], [1, "#ff000000"]],
+//		"alpha": [[0, 1], [1, 0]],
+//		"size": [[0, 12], [1, 2]],
+//		"shape": "circle",
+//		"image": "spark.png",
+//		"source": [0, 0, 16, 16],
+//		"blend": "add",
+//		"max": 500
+//	}
+//
+// Ranges and curves can also be a single number.
+type particleJSON struct {
+	Rate     float64         `json:"rate"`
+	Lifetime *jsonRange      `json:"lifetime"`
+	Speed    *jsonRange      `json:"speed"`
+	Angle    *jsonRange      `json:"angle"`
+	Gravity  []float64       `json:"gravity"`
+	Colors   json.RawMessage `json:"colors"`
+	Alpha    *jsonCurve      `json:"alpha"`
+	Size     *jsonCurve      `json:"size"`
+	Shape    string          `json:"shape"`
+	Image    string          `json:"image"`
+	Source   []int           `json:"source"`
+	Blend    string          `json:"blend"`
+	Max      int             `json:"max"`
+}
+
+type jsonRange Range
+
+func (r *jsonRange) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		*r = jsonRange{v, v}
+		return nil
+	}
+	var pair []float64
+	if err := json.Unmarshal(data, &pair); err != nil || len(pair) != 2 {
+		return fmt.Errorf("Range must be a number or [min, max]")
+	}
+	*r = jsonRange{pair[0], pair[1]}
+	return nil
+}
+
+type jsonCurve Curve
+
+func (c *jsonCurve) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		*c = jsonCurve{{0, v}}
+		return nil
+	}
+	var points [][2]float64
+	if err := json.Unmarshal(data, &points); err != nil {
+		return fmt.Errorf("Curve must be a number or a list of [offset, value]")
+	}
+	var curve []CurvePoint
+	for _, p := range points {
+		curve = append(curve, CurvePoint{p[0], p[1]})
+	}
+	*c = jsonCurve(NewCurve(curve...))
+	return nil
+}
+
+// parseHexColor parses a colour written as
+// #rrggbb or #rrggbbaa.
+func parseHexColor(s string) (*Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return nil, fmt.Errorf("Bad colour '%s'", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Bad colour '%s'", s)
+	}
+	if len(hex) == 6 {
+		return HexRGB(uint32(v)), nil
+	}
+	return &Color{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}, nil
+}
+
+// parseColorStops parses colours as either one colour
+// or a list of [offset, colour].
+func parseColorStops(data json.RawMessage) ([]ColorStop, error) {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		col, err := parseHexColor(single)
+		if err != nil {
+			return nil, err
+		}
+		return []ColorStop{{0, col}}, nil
+	}
+
+	var stops [][2]interface{}
+	if err := json.Unmarshal(data, &stops); err != nil {
+		return nil, fmt.Errorf("Colors must be a colour or a list of [offset, colour]")
+	}
+	var result []ColorStop
+	for _, stop := range stops {
+		offset, ok := stop[0].(float64)
+		hex, isString := stop[1].(string)
+		if !ok || !isString {
+			return nil, fmt.Errorf("Colors must be a colour or a list of [offset, colour]")
+		}
+		col, err := parseHexColor(hex)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ColorStop{offset, col})
+	}
+	return sortStops(result), nil
+}
+
+var blendNames = map[string]BlendMode{
+	"alpha":    BlendAlpha,
+	"none":     BlendNone,
+	"add":      BlendAdd,
+	"modulate": BlendModulate,
+	"multiply": BlendMultiply,
+}
+
+// LoadParticleEmitter loads the particle emitter defined by the
+// JSON file at the given path. Any image it uses is loaded relative
+// to the file, and is freed when the emitter is destroyed.
+func LoadParticleEmitter(path string) (*ParticleEmitter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load particle emitter '%s'", path)
+	}
+	return parseParticleEmitter(data, filepath.Dir(path))
+}
+
+// ParseParticleEmitter creates a particle emitter from the given
+// JSON definition. Any image path in it is relative to the
+// working directory.
+func ParseParticleEmitter(data []byte) (*ParticleEmitter, error) {
+	return parseParticleEmitter(data, "")
+}
+
+func parseParticleEmitter(data []byte, dir string) (*ParticleEmitter, error) {
+	var def particleJSON
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("Failed to parse particle emitter: %s", err)
+	}
+
+	e := NewParticleEmitter(0, 0)
+	e.Rate = def.Rate
+	e.MaxParticles = def.Max
+	if def.Lifetime != nil {
+		e.Lifetime = Range(*def.Lifetime)
+	}
+	if def.Speed != nil {
+		e.Speed = Range(*def.Speed)
+	}
+	if def.Angle != nil {
+		e.Angle = Range(*def.Angle)
+	}
+	if def.Alpha != nil {
+		e.Alpha = Curve(*def.Alpha)
+	}
+	if def.Size != nil {
+		e.Size = Curve(*def.Size)
+	}
+
+	switch len(def.Gravity) {
+	case 0:
+	case 2:
+		e.Gravity = Point{def.Gravity[0], def.Gravity[1]}
+	default:
+		return nil, fmt.Errorf("Gravity must be [x, y]")
+	}
+
+	if len(def.Colors) > 0 {
+		stops, err := parseColorStops(def.Colors)
+		if err != nil {
+			return nil, err
+		}
+		e.Colors = stops
+	}
+
+	switch def.Shape {
+	case "", "square":
+		e.Shape = ParticleSquare
+	case "circle":
+		e.Shape = ParticleCircle
+	default:
+		return nil, fmt.Errorf("Unknown particle shape '%s'", def.Shape)
+	}
+
+	if def.Blend != "" {
+		mode, ok := blendNames[def.Blend]
+		if !ok {
+			return nil, fmt.Errorf("Unknown blend mode '%s'", def.Blend)
+		}
+		e.Blend = mode
+	}
+
+	switch len(def.Source) {
+	case 0:
+	case 4:
+		e.Source = &Rectangle{def.Source[0], def.Source[1], def.Source[2], def.Source[3]}
+	default:
+		return nil, fmt.Errorf("Source must be [x, y, w, h]")
+	}
+
+	if def.Image != "" {
+		path := def.Image
+		if dir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		image, err := LoadImage(path)
+		if err != nil {
+			return nil, err
+		}
+		e.Image = image
+		e.ownsImage = true
+	}
+
+	return e, nil
+}
@@ -0,0 +1,278 @@
+package strife
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ParticleShape is the shape that particles are drawn
+// as when an emitter has no image.
+type ParticleShape int
+
+// Types of particle shapes
+const (
+	ParticleSquare ParticleShape = iota
+	ParticleCircle
+)
+
+// Range is a range of values that a random
+// value is picked from.
+type Range struct {
+	Min, Max float64
+}
+
+// random picks a value from the range
+func (r Range) random(rng *rand.Rand) float64 {
+	return r.Min + (r.Max-r.Min)*rng.Float64()
+}
+
+// CurvePoint is a value at a point in a particle's
+// life, from 0 when it is emitted to 1 when it dies.
+type CurvePoint struct {
+	Offset, Value float64
+}
+
+// Curve is a value that changes over the life of a particle,
+// the value is blended between the points of the curve.
+type Curve []CurvePoint
+
+// NewCurve creates a curve from the given points
+func NewCurve(points ...CurvePoint) Curve {
+	curve := append(Curve{}, points...)
+	sort.SliceStable(curve, func(i, j int) bool {
+		return curve[i].Offset < curve[j].Offset
+	})
+	return curve
+}
+
+// At returns the value of the curve at offset t, an empty
+// curve has the value 1.
+func (c Curve) At(t float64) float64 {
+	if len(c) == 0 {
+		return 1
+	}
+	if t <= c[0].Offset {
+		return c[0].Value
+	}
+	for i := 1; i < len(c); i++ {
+		a, b := c[i-1], c[i]
+		if t > b.Offset {
+			continue
+		}
+		if b.Offset <= a.Offset {
+			return b.Value
+		}
+		return a.Value + (b.Value-a.Value)*(t-a.Offset)/(b.Offset-a.Offset)
+	}
+	return c[len(c)-1].Value
+}
+
+type particle struct {
+	x, y, vx, vy float64
+	age, life    float64
+}
+
+// ParticleEmitter emits particles that move under gravity and
+// change colour, alpha and size over their life. Particles are
+// moved along by calling Update every frame, and are drawn all
+// at once with Renderer.Particles.
+// X, Y => Where particles are emitted from;
+// Rate => How many particles are emitted each second;
+// Lifetime => How long each particle lives for in seconds;
+// Speed, Angle => How fast in pixels per second and in which
+// direction, in degrees clockwise from the right, particles are emitted;
+// Gravity => How much the particles accelerate by each second;
+// Colors => The colour of the particles over their life;
+// Alpha, Size => The alpha from 0 to 1 and the size in pixels
+// of the particles over their life;
+// Shape, Image, Source => What the particles look like, if Image is
+// set then the Source area of the image is drawn, or the whole image
+// if Source is nil, otherwise the particles are drawn as Shape;
+// Blend => How the particles are blended with what is underneath; and
+// MaxParticles => The most particles that can be alive at once,
+// 0 for no limit.
+type ParticleEmitter struct {
+	X, Y         float64
+	Rate         float64
+	Lifetime     Range
+	Speed        Range
+	Angle        Range
+	Gravity      Point
+	Colors       []ColorStop
+	Alpha        Curve
+	Size         Curve
+	Shape        ParticleShape
+	Image        *Image
+	Source       *Rectangle
+	Blend        BlendMode
+	MaxParticles int
+
+	particles []particle
+	pending   float64
+	rng       *rand.Rand
+	ownsImage bool
+}
+
+// NewParticleEmitter creates an emitter at x, y that emits
+// white square particles in every direction. The emitter
+// doesn't emit anything until its Rate is set or Burst is called.
+func NewParticleEmitter(x, y float64) *ParticleEmitter {
+	return &ParticleEmitter{
+		X:        x,
+		Y:        y,
+		Lifetime: Range{1, 1},
+		Speed:    Range{50, 100},
+		Angle:    Range{0, 360},
+		Colors:   []ColorStop{{0, White}},
+		Size:     Curve{{0, 4}},
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Burst emits the given number of particles at once
+func (e *ParticleEmitter) Burst(count int) {
+	if e.rng == nil {
+		e.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	for i := 0; i < count; i++ {
+		if e.MaxParticles > 0 && len(e.particles) >= e.MaxParticles {
+			return
+		}
+
+		speed := e.Speed.random(e.rng)
+		sin, cos := math.Sincos(e.Angle.random(e.rng) * math.Pi / 180)
+		e.particles = append(e.particles, particle{
+			x:    e.X,
+			y:    e.Y,
+			vx:   cos * speed,
+			vy:   sin * speed,
+			life: e.Lifetime.random(e.rng),
+		})
+	}
+}
+
+// Update moves the particles along by the given amount of
+// time, removing the ones that have died and emitting new ones.
+func (e *ParticleEmitter) Update(delta time.Duration) {
+	dt := delta.Seconds()
+
+	alive := e.particles[:0]
+	for _, p := range e.particles {
+		p.age += dt
+		if p.age >= p.life {
+			continue
+		}
+		p.vx += e.Gravity.X * dt
+		p.vy += e.Gravity.Y * dt
+		p.x += p.vx * dt
+		p.y += p.vy * dt
+		alive = append(alive, p)
+	}
+	e.particles = alive
+
+	e.pending += e.Rate * dt
+	if count := int(e.pending); count > 0 {
+		e.pending -= float64(count)
+		e.Burst(count)
+	}
+}
+
+// Count returns how many particles are alive
+func (e *ParticleEmitter) Count() int {
+	return len(e.particles)
+}
+
+// Clear removes all of the particles
+func (e *ParticleEmitter) Clear() {
+	e.particles = e.particles[:0]
+	e.pending = 0
+}
+
+// Destroy must be invoked when finished with an emitter that
+// was loaded with LoadParticleEmitter, as it frees its image.
+func (e *ParticleEmitter) Destroy() {
+	if e.ownsImage && e.Image != nil {
+		e.Image.Destroy()
+		e.Image = nil
+	}
+}
+
+// Particles will render all of the particles of the given
+// emitter in one go.
+func (r *Renderer) Particles(e *ParticleEmitter) {
+	if len(e.particles) == 0 {
+		return
+	}
+
+	var texture *sdl.Texture
+	var u0, v0, u1, v1 float32
+	aspect := float32(1)
+	if e.Image != nil {
+		texture = e.Image.Texture
+		src := Rectangle{0, 0, e.Image.Width, e.Image.Height}
+		if e.Source != nil {
+			src = *e.Source
+		}
+		w, h := float32(e.Image.Width), float32(e.Image.Height)
+		u0, v0 = float32(src.X)/w, float32(src.Y)/h
+		u1, v1 = float32(src.X+src.W)/w, float32(src.Y+src.H)/h
+		if src.W > 0 {
+			aspect = float32(src.H) / float32(src.W)
+		}
+	}
+
+	segments, perParticle := 0, 6
+	if texture == nil && e.Shape == ParticleCircle {
+		segments, perParticle = 12, 36
+	}
+
+	verts := make([]sdl.Vertex, 0, len(e.particles)*perParticle)
+	for _, p := range e.particles {
+		t := p.age / p.life
+		col := gradientColor(e.Colors, t)
+		col.A = uint8(math.Round(float64(col.A) * math.Max(0, math.Min(1, e.Alpha.At(t)))))
+		half := float32(e.Size.At(t) / 2)
+		x, y := float32(p.x), float32(p.y)
+
+		if segments > 0 {
+			centre := sdl.Vertex{Position: sdl.FPoint{x, y}, Color: col}
+			for i := 0; i < segments; i++ {
+				a0 := float64(i) / float64(segments) * 2 * math.Pi
+				a1 := float64(i+1) / float64(segments) * 2 * math.Pi
+				verts = append(verts, centre,
+					sdl.Vertex{Position: sdl.FPoint{x + half*float32(math.Cos(a0)), y + half*float32(math.Sin(a0))}, Color: col},
+					sdl.Vertex{Position: sdl.FPoint{x + half*float32(math.Cos(a1)), y + half*float32(math.Sin(a1))}, Color: col},
+				)
+			}
+			continue
+		}
+
+		// images keep their shape, the size is their width
+		halfH := half * aspect
+		tl := sdl.Vertex{Position: sdl.FPoint{x - half, y - halfH}, Color: col, TexCoord: sdl.FPoint{u0, v0}}
+		tr := sdl.Vertex{Position: sdl.FPoint{x + half, y - halfH}, Color: col, TexCoord: sdl.FPoint{u1, v0}}
+		br := sdl.Vertex{Position: sdl.FPoint{x + half, y + halfH}, Color: col, TexCoord: sdl.FPoint{u1, v1}}
+		bl := sdl.Vertex{Position: sdl.FPoint{x - half, y + halfH}, Color: col, TexCoord: sdl.FPoint{u0, v1}}
+		verts = append(verts, tl, tr, br, tl, br, bl)
+	}
+
+	// the tint and alpha of the renderer are applied
+	// to the vertices by geometry.
+	if texture != nil {
+		mod := tintColor(White.ToSDLColor(), e.Image.tint, e.Image.GetAlpha())
+		setTextureBlend(texture, e.Blend)
+		texture.SetColorMod(mod.R, mod.G, mod.B)
+		texture.SetAlphaMod(mod.A)
+		r.geometry(texture, verts)
+		return
+	}
+
+	blend := r.blend
+	r.SetBlendMode(e.Blend)
+	r.geometry(nil, verts)
+	r.SetBlendMode(blend)
+}
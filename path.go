@@ -0,0 +1,385 @@
+package strife
+
+import (
+	"math"
+	"sort"
+)
+
+// FillRule decides which parts of a path are
+// inside of it when the path is filled.
+type FillRule int
+
+// Types of fill rules, NonZero fills anything the path
+// winds around and EvenOdd leaves holes where shapes
+// overlap.
+const (
+	NonZero FillRule = iota
+	EvenOdd
+)
+
+// DefaultTolerance is the default distance in pixels that a
+// flattened curve can stray from the actual curve.
+const DefaultTolerance = 0.25
+
+type pathOp int
+
+const (
+	opMove pathOp = iota
+	opLine
+	opQuad
+	opCubic
+	opClose
+)
+
+type pathCommand struct {
+	op     pathOp
+	points [3]Point
+}
+
+// Path is a vector shape made from lines and curves, it
+// is built up with MoveTo, LineTo, QuadTo, CubicTo,
+// ArcTo and Close and then drawn with StrokePath or FillPath.
+// Tolerance => How far in pixels the curves can stray when flattened,
+// if zero then DefaultTolerance is used; and
+// FillRule => How the inside of the path is worked out when filling.
+type Path struct {
+	Tolerance float64
+	FillRule  FillRule
+
+	commands []pathCommand
+	start    Point
+	current  Point
+	open     bool
+}
+
+// NewPath creates an empty path
+func NewPath() *Path {
+	return &Path{}
+}
+
+// MoveTo starts a new sub-path at the given x, y co-ordinates
+func (p *Path) MoveTo(x, y float64) {
+	p.commands = append(p.commands, pathCommand{op: opMove, points: [3]Point{{x, y}}})
+	p.start = Point{x, y}
+	p.current = p.start
+	p.open = true
+}
+
+// ensureOpen will start a sub-path at the current point if
+// a drawing command is issued without a MoveTo.
+func (p *Path) ensureOpen() {
+	if !p.open {
+		p.MoveTo(p.current.X, p.current.Y)
+	}
+}
+
+// LineTo adds a straight line from the current
+// point to the given x, y co-ordinates
+func (p *Path) LineTo(x, y float64) {
+	p.ensureOpen()
+	p.commands = append(p.commands, pathCommand{op: opLine, points: [3]Point{{x, y}}})
+	p.current = Point{x, y}
+}
+
+// QuadTo adds a quadratic bezier curve from the current point
+// to x, y with the control point cx, cy.
+func (p *Path) QuadTo(cx, cy, x, y float64) {
+	p.ensureOpen()
+	p.commands = append(p.commands, pathCommand{op: opQuad, points: [3]Point{{cx, cy}, {x, y}}})
+	p.current = Point{x, y}
+}
+
+// CubicTo adds a cubic bezier curve from the current point
+// to x, y with the control points c1x, c1y and c2x, c2y.
+func (p *Path) CubicTo(c1x, c1y, c2x, c2y, x, y float64) {
+	p.ensureOpen()
+	p.commands = append(p.commands, pathCommand{op: opCubic, points: [3]Point{{c1x, c1y}, {c2x, c2y}, {x, y}}})
+	p.current = Point{x, y}
+}
+
+// ArcTo adds a circular arc of the given radius that is tangent
+// to the line from the current point to x1, y1 and the line from
+// x1, y1 to x2, y2. A straight line joins the current point to the
+// start of the arc. This works the same as arcTo in the HTML canvas,
+// and is handy for rounding off corners.
+func (p *Path) ArcTo(x1, y1, x2, y2, radius float64) {
+	p.ensureOpen()
+
+	p0, p1, p2 := p.current, Point{x1, y1}, Point{x2, y2}
+	d0 := p0.sub(p1).normalize()
+	d1 := p2.sub(p1).normalize()
+
+	// if any of the points are the same, or the lines are
+	// parallel there is no arc to draw.
+	turn := cross(p0, p1, p2)
+	if radius <= 0 || d0 == (Point{}) || d1 == (Point{}) || math.Abs(turn) < 1e-9 {
+		p.LineTo(x1, y1)
+		return
+	}
+
+	// the arc touches both lines at the same distance from
+	// the corner, and its centre is along the bisector.
+	angle := math.Acos(math.Max(-1, math.Min(1, d0.dot(d1))))
+	dist := radius / math.Tan(angle/2)
+	t0 := p1.add(d0.mul(dist))
+	t1 := p1.add(d1.mul(dist))
+	centre := p1.add(d0.add(d1).normalize().mul(radius / math.Sin(angle/2)))
+
+	p.LineTo(t0.X, t0.Y)
+
+	start := math.Atan2(t0.Y-centre.Y, t0.X-centre.X)
+	sweep := math.Atan2(t1.Y-centre.Y, t1.X-centre.X) - start
+	for sweep > math.Pi {
+		sweep -= 2 * math.Pi
+	}
+	for sweep < -math.Pi {
+		sweep += 2 * math.Pi
+	}
+
+	// each quarter turn (or less) of the arc is
+	// approximated with a cubic bezier.
+	n := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2)))
+	step := sweep / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	for i := 0; i < n; i++ {
+		a := start + step*float64(i)
+		b := a + step
+		ca, sa := math.Cos(a), math.Sin(a)
+		cb, sb := math.Cos(b), math.Sin(b)
+		p.CubicTo(
+			centre.X+radius*(ca-k*sa), centre.Y+radius*(sa+k*ca),
+			centre.X+radius*(cb+k*sb), centre.Y+radius*(sb-k*cb),
+			centre.X+radius*cb, centre.Y+radius*sb,
+		)
+	}
+}
+
+// Close closes the current sub-path by joining it back
+// to where it started.
+func (p *Path) Close() {
+	if !p.open {
+		return
+	}
+	p.commands = append(p.commands, pathCommand{op: opClose})
+	p.current = p.start
+	p.open = false
+}
+
+// subpath is a flattened part of a path
+type subpath struct {
+	points []Point
+	closed bool
+}
+
+// tolerance returns the tolerance to flatten the path with
+// in its own co-ordinates, so that it is accurate on screen
+// under the given transform.
+func (p *Path) tolerance(m Matrix) float64 {
+	tolerance := p.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	if scale := m.scale(); scale > 0 {
+		tolerance /= scale
+	}
+	return tolerance
+}
+
+// flatten will turn all of the curves in the path into
+// straight lines within the given tolerance.
+func (p *Path) flatten(tolerance float64) []subpath {
+	var result []subpath
+	var cur *subpath
+	var pos Point
+
+	for _, cmd := range p.commands {
+		switch cmd.op {
+		case opMove:
+			result = append(result, subpath{points: []Point{cmd.points[0]}})
+			cur = &result[len(result)-1]
+			pos = cmd.points[0]
+			continue
+		case opLine:
+			cur.points = append(cur.points, cmd.points[0])
+		case opQuad:
+			cur.points = flattenQuad(cur.points, pos, cmd.points[0], cmd.points[1], tolerance)
+		case opCubic:
+			cur.points = flattenCubic(cur.points, pos, cmd.points[0], cmd.points[1], cmd.points[2], tolerance)
+		case opClose:
+			cur.closed = true
+			continue
+		}
+		pos = cur.points[len(cur.points)-1]
+	}
+	return result
+}
+
+// flattenQuad appends the points along the quadratic curve
+// p0, p1, p2 to the given list, not including p0.
+func flattenQuad(points []Point, p0, p1, p2 Point, tolerance float64) []Point {
+	dd := p0.sub(p1.mul(2)).add(p2).length()
+	n := int(math.Max(1, math.Ceil(math.Sqrt(dd/(4*tolerance)))))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		points = append(points, p0.mul(u*u).add(p1.mul(2*u*t)).add(p2.mul(t*t)))
+	}
+	return points
+}
+
+// flattenCubic appends the points along the cubic curve
+// p0, p1, p2, p3 to the given list, not including p0.
+func flattenCubic(points []Point, p0, p1, p2, p3 Point, tolerance float64) []Point {
+	dd := math.Max(
+		p0.sub(p1.mul(2)).add(p2).length(),
+		p1.sub(p2.mul(2)).add(p3).length(),
+	)
+	n := int(math.Max(1, math.Ceil(math.Sqrt(3*dd/(4*tolerance)))))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		points = append(points, p0.mul(u*u*u).
+			add(p1.mul(3*u*u*t)).
+			add(p2.mul(3*u*t*t)).
+			add(p3.mul(t*t*t)))
+	}
+	return points
+}
+
+// StrokePath will draw the outline of the given path with
+// the given stroke in the current colour.
+func (r *Renderer) StrokePath(path *Path, stroke Stroke) {
+	var tris []Point
+	for _, sub := range path.flatten(path.tolerance(r.transform)) {
+		tris = append(tris, strokeTriangles(sub.points, stroke, sub.closed)...)
+	}
+	r.fillStroke(tris)
+}
+
+// FillPath will fill the given path in the current colour. Any
+// sub-paths that are not closed are closed automatically.
+func (r *Renderer) FillPath(path *Path) {
+	r.fillTriangles(fillTrapezoids(path.flatten(path.tolerance(r.transform)), path.FillRule))
+}
+
+// pathEdge is a non-horizontal edge of a filled path, it
+// always runs from top to bottom. dir records which way
+// the edge originally went for the winding count.
+type pathEdge struct {
+	top, bottom Point
+	dir         int
+}
+
+func (e pathEdge) xAt(y float64) float64 {
+	t := (y - e.top.Y) / (e.bottom.Y - e.top.Y)
+	return e.top.X + (e.bottom.X-e.top.X)*t
+}
+
+// fillTrapezoids will split the area inside of the given
+// sub-paths into a list of triangles. The area is cut into
+// horizontal bands at every point where an edge starts, ends
+// or crosses another edge, and within each band the edges
+// are paired up into trapezoids following the fill rule.
+func fillTrapezoids(subpaths []subpath, rule FillRule) []Point {
+	var edges []pathEdge
+	var ys []float64
+	for _, sub := range subpaths {
+		n := len(sub.points)
+		for i := 0; i < n; i++ {
+			a, b := sub.points[i], sub.points[(i+1)%n]
+			ys = append(ys, a.Y)
+			if a.Y == b.Y {
+				continue
+			}
+			if a.Y < b.Y {
+				edges = append(edges, pathEdge{a, b, 1})
+			} else {
+				edges = append(edges, pathEdge{b, a, -1})
+			}
+		}
+	}
+
+	// any edges that cross need a band to start
+	// at the crossing so they can swap over.
+	for i := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			if y, ok := edgeCrossing(edges[i], edges[j]); ok {
+				ys = append(ys, y)
+			}
+		}
+	}
+
+	sort.Float64s(ys)
+	var tris []Point
+	active := make([]pathEdge, 0, len(edges))
+	for i := 0; i+1 < len(ys); i++ {
+		y0, y1 := ys[i], ys[i+1]
+		if y1-y0 < 1e-9 {
+			continue
+		}
+		mid := (y0 + y1) / 2
+
+		active = active[:0]
+		for _, e := range edges {
+			if e.top.Y <= mid && e.bottom.Y >= mid {
+				active = append(active, e)
+			}
+		}
+		sort.Slice(active, func(a, b int) bool {
+			return active[a].xAt(mid) < active[b].xAt(mid)
+		})
+
+		winding := 0
+		for j, e := range active {
+			was := inside(winding, rule)
+			winding += e.dir
+			if was || !inside(winding, rule) || j+1 >= len(active) {
+				continue
+			}
+
+			// find the edge where we leave the shape.
+			k := j + 1
+			w := winding
+			for ; k < len(active); k++ {
+				w += active[k].dir
+				if !inside(w, rule) {
+					break
+				}
+			}
+			if k >= len(active) {
+				continue
+			}
+
+			l, r := e, active[k]
+			tl, tr := Point{l.xAt(y0), y0}, Point{r.xAt(y0), y0}
+			bl, br := Point{l.xAt(y1), y1}, Point{r.xAt(y1), y1}
+			tris = append(tris, tl, tr, br, tl, br, bl)
+		}
+	}
+	return tris
+}
+
+func inside(winding int, rule FillRule) bool {
+	if rule == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// edgeCrossing returns the y co-ordinate where the two
+// edges cross, if they do.
+func edgeCrossing(a, b pathEdge) (float64, bool) {
+	r := a.bottom.sub(a.top)
+	s := b.bottom.sub(b.top)
+	denom := r.X*s.Y - r.Y*s.X
+	if denom == 0 {
+		return 0, false
+	}
+	qp := b.top.sub(a.top)
+	t := (qp.X*s.Y - qp.Y*s.X) / denom
+	u := (qp.X*r.Y - qp.Y*r.X) / denom
+	if t <= 0 || t >= 1 || u <= 0 || u >= 1 {
+		return 0, false
+	}
+	return a.top.Y + r.Y*t, true
+}
@@ -0,0 +1,96 @@
+package strife
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PixelImage is an image whose pixels can be changed every
+// frame, for things like procedural textures, video frames or
+// emulator screens. The pixels are kept in memory as RGBA and
+// are uploaded to the GPU on Unlock or Update. As a pixel image
+// wraps an Image it can be drawn with Renderer.Image,
+// e.g. r.Image(pixels.Image, x, y).
+type PixelImage struct {
+	*Image
+	locked bool
+}
+
+// NewPixelImage will create a pixel image of the given size. The
+// image starts off fully transparent. It will return the image
+// and any errors encountered.
+func NewPixelImage(w, h int) (*PixelImage, error) {
+	if RenderInstance == nil {
+		return nil, fmt.Errorf("Render context has not been initialized yet.")
+	}
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("Pixel image size must be positive, not %dx%d", w, h)
+	}
+
+	texture, err := RenderInstance.CreateTexture(uint32(sdl.PIXELFORMAT_RGBA32), sdl.TEXTUREACCESS_STREAMING, int32(w), int32(h))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create pixel image of size %dx%d", w, h)
+	}
+	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+	RenderInstance.textureAllocated()
+
+	pixels := &PixelImage{Image: newImage(texture, nil, w, h)}
+	pixels.pixels = image.NewNRGBA(image.Rect(0, 0, w, h))
+	if err := pixels.upload(); err != nil {
+		pixels.Destroy()
+		return nil, err
+	}
+	return pixels, nil
+}
+
+// Lock returns the pixels of the image so they can be changed.
+// The pixels are RGBA, not premultiplied, and keep what was
+// in them last. None of the changes are shown until Unlock
+// is called.
+func (p *PixelImage) Lock() *image.NRGBA {
+	p.locked = true
+	return p.pixels
+}
+
+// Unlock uploads all of the pixels to the texture, not only the
+// ones that were changed. Use Update to change a small area.
+func (p *PixelImage) Unlock() error {
+	if !p.locked {
+		return fmt.Errorf("Pixel image is not locked")
+	}
+	p.locked = false
+	return p.upload()
+}
+
+// Update replaces the pixels in the given area with the given
+// RGBA pixels, which are rows of area.W pixels one after another.
+// The area must be inside of the image.
+func (p *PixelImage) Update(area Rectangle, pixels []byte) error {
+	bounds := image.Rect(area.X, area.Y, area.X+area.W, area.Y+area.H)
+	if bounds.Empty() || !bounds.In(p.pixels.Rect) {
+		return fmt.Errorf("Area %v is not inside of the %dx%d pixel image", area, p.Width, p.Height)
+	}
+	stride := area.W * 4
+	if len(pixels) < stride*area.H {
+		return fmt.Errorf("Expected %d bytes of pixels for the area, not %d", stride*area.H, len(pixels))
+	}
+
+	offset := p.pixels.PixOffset(area.X, area.Y)
+	copyRows(p.pixels.Pix[offset:], p.pixels.Stride, pixels, stride, stride, area.H)
+
+	rect := &sdl.Rect{int32(area.X), int32(area.Y), int32(area.W), int32(area.H)}
+	if err := p.Texture.Update(rect, pixels, stride); err != nil {
+		return fmt.Errorf("Failed to update pixel image: %s", err)
+	}
+	return nil
+}
+
+// upload copies all of the pixels to the texture
+func (p *PixelImage) upload() error {
+	if err := p.Texture.Update(nil, p.pixels.Pix, p.pixels.Stride); err != nil {
+		return fmt.Errorf("Failed to update pixel image: %s", err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 // Style to render
@@ -26,12 +27,15 @@ const (
 // RenderConfig is the configuration settings
 // for the renderer.
 // Alias => Controls if the fonts are smoothed;
-// Accelerated => The renderer is hardware accelerated if true; and
-// VerticalSync => Will synchronize the FPS with the monitors refresh rate
+// Accelerated => The renderer is hardware accelerated if true;
+// VerticalSync => Will synchronize the FPS with the monitors refresh rate; and
+// Headless => The window is drawn with the software renderer,
+// see HeadlessConfig.
 type RenderConfig struct {
 	Alias        bool
 	Accelerated  bool
 	VerticalSync bool
+	Headless     bool
 }
 
 // GoGoStrifeFast is the default configuration
@@ -58,6 +62,48 @@ func DefaultConfig() *RenderConfig {
 	}
 }
 
+// headlessDrivers are the SDL video drivers that
+// work without a display, in order of preference.
+var headlessDrivers = []string{"offscreen", "dummy"}
+
+// HeadlessConfig is a configuration for rendering without a
+// display, e.g. for tests on a machine with no GPU. It sets up SDL
+// with its offscreen video driver, or its dummy driver if offscreen
+// is not available, and draws with the software renderer. The video
+// driver can only be chosen when SDL's video is first set up, so an
+// error is returned if it is already set up with another driver.
+func HeadlessConfig() (*RenderConfig, error) {
+	config := &RenderConfig{
+		Alias:    true,
+		Headless: true,
+	}
+
+	if sdl.WasInit(sdl.INIT_VIDEO) != 0 {
+		driver, _ := sdl.GetCurrentVideoDriver()
+		for _, headless := range headlessDrivers {
+			if driver == headless {
+				return config, nil
+			}
+		}
+		return nil, fmt.Errorf("Video is already set up with the '%s' driver", driver)
+	}
+
+	prev, hadPrev := os.LookupEnv("SDL_VIDEODRIVER")
+	for _, driver := range headlessDrivers {
+		os.Setenv("SDL_VIDEODRIVER", driver)
+		if err := sdl.Init(sdl.INIT_VIDEO); err == nil {
+			return config, nil
+		}
+	}
+
+	if hadPrev {
+		os.Setenv("SDL_VIDEODRIVER", prev)
+	} else {
+		os.Unsetenv("SDL_VIDEODRIVER")
+	}
+	return nil, fmt.Errorf("Failed to set up a headless video driver: %s", sdl.GetError())
+}
+
 // Renderer contains the
 // renderers current configuration, as well
 // as the colour state and font state and a wrapper over
@@ -66,27 +112,57 @@ type Renderer struct {
 	RenderConfig
 	*sdl.Renderer
 
-	color *Color
-	font  *Font
+	color  *Color
+	paint  Paint
+	font   *Font
+	target *Canvas
+
+	transform  Matrix
+	transforms []Matrix
+	clips      []*sdl.Rect
+
+	blend BlendMode
+	tint  *Color
+	alpha uint8
+
+	logical      *Canvas
+	logicalScale LogicalScale
+
+	// scratch is drawn into by strokes that
+	// can't be drawn straight to the target.
+	scratch *Canvas
+
+	stats       FrameStats
+	lastStats   FrameStats
+	lastTexture *sdl.Texture
+	frameStart  time.Time
+	frameTimes  []time.Duration
+	showStats   bool
 }
 
-// Clear will clear the screen to black. By default
-// it will immediately set the colour state to render
-// things as white.
+// Clear will clear the screen to black. The whole screen is
+// cleared to opaque black whatever the transform, clip, blend
+// mode, tint and alpha are. By default it will immediately set
+// the colour state to render things as white.
 func (r *Renderer) Clear() {
-	r.SetColor(Black)
-	w, h, err := r.Renderer.GetOutputSize()
-	if err != nil {
+	r.SetDrawColor(0, 0, 0, 255)
+	if err := r.Renderer.Clear(); err != nil {
 		panic(err)
 	}
-	r.Rect(0, 0, int(w), int(h), Fill)
 
 	r.SetColor(White)
 }
 
-// GetSize returns the size of the renderer. on error
-// it will return -1, -1
+// GetSize returns the size of the renderer, or the size
+// of the canvas if one is set as the target, or the logical
+// size if one is set. on error it will return -1, -1
 func (r *Renderer) GetSize() (int, int) {
+	if r.target != nil {
+		return r.target.Width, r.target.Height
+	}
+	if r.logical != nil {
+		return r.logical.Width, r.logical.Height
+	}
 	w, h, err := r.Renderer.GetOutputSize()
 	if err != nil {
 		return -1, -1
@@ -96,24 +172,76 @@ func (r *Renderer) GetSize() (int, int) {
 
 // Display the renderer to the window
 func (r *Renderer) Display() {
+	r.endFrame()
+	if r.showStats {
+		r.drawStats()
+	}
+	defer r.startFrame()
+
+	if r.logical == nil {
+		r.Renderer.Present()
+		return
+	}
+
+	r.presentLogical()
 	r.Renderer.Present()
+
+	// go back to drawing where we were
+	if r.target != nil {
+		r.SetRenderTarget(r.target.Texture)
+	} else {
+		r.SetRenderTarget(r.logical.Texture)
+		r.applyClip()
+	}
+	r.applyDrawColor()
 }
 
-// SetColor sets the current colour state
+// SetColor sets the current colour state, this
+// will also clear any paint that has been set.
 func (r *Renderer) SetColor(color *Color) {
 	r.color = color
-	r.SetDrawColor(color.R, color.G, color.B, color.A)
+	r.paint = nil
+	r.applyDrawColor()
 }
 
 // Rect will draw a rectangle at the given x, y co-ordinates
 // of the specified size. It takes the mode to render the
 // rectangle as: fill or line.
 func (r *Renderer) Rect(x, y, w, h int, mode Style) {
-	if mode == Line {
-		r.DrawRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
-	} else {
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+	if r.paint != nil {
+		fx, fy, fw, fh := float64(x), float64(y), float64(w), float64(h)
+		if mode == Line {
+			outline := []Point{
+				{fx + 0.5, fy + 0.5}, {fx + fw - 0.5, fy + 0.5},
+				{fx + fw - 0.5, fy + fh - 0.5}, {fx + 0.5, fy + fh - 0.5},
+			}
+			r.fillStroke(strokeTriangles(outline, Stroke{Width: 1}, true))
+		} else {
+			r.fillConvex([]Point{{fx, fy}, {fx + fw, fy}, {fx + fw, fy + fh}, {fx, fy + fh}}, false)
+		}
+		return
+	}
+
+	if mode == Fill {
+		r.fillRects([]sdl.Rect{{int32(x), int32(y), int32(w), int32(h)}})
+		return
 	}
+
+	if r.clippedAway() {
+		return
+	}
+	if ox, oy, ok := r.offset(); ok {
+		r.DrawRect(&sdl.Rect{int32(x) + ox, int32(y) + oy, int32(w), int32(h)})
+		r.countDraw(nil)
+		return
+	}
+
+	fx, fy, fw, fh := float64(x), float64(y), float64(w), float64(h)
+	r.drawLines([]sdl.FPoint{
+		{float32(fx), float32(fy)}, {float32(fx + fw), float32(fy)},
+		{float32(fx + fw), float32(fy + fh)}, {float32(fx), float32(fy + fh)},
+		{float32(fx), float32(fy)},
+	})
 }
 
 // GetFont returns the current font that was last set
@@ -126,15 +254,6 @@ func (r *Renderer) SetFont(font *Font) {
 	r.font = font
 }
 
-func maxInt32(a, b int32) int32 {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-var allocs int
-
 func (r *Renderer) renderRune(color *Color, char rune) (*sdl.Texture, []int32) {
 	message := string(char)
 
@@ -163,9 +282,7 @@ func (r *Renderer) renderRune(color *Color, char rune) (*sdl.Texture, []int32) {
 	// some of the unused textures every now and then?
 	// or an LRU cache or something?
 	// or memory pool allocation?
-	allocs++
-
-	// log.Println("allocs ", allocs)
+	r.textureAllocated()
 
 	return texture, []int32{surface.W, surface.H}
 }
@@ -185,19 +302,34 @@ func (r *Renderer) Text(message string, x, y int) (int, int) {
 
 	var width, height int32
 
-	col := r.color.AsHex()
+	// painted text is rendered in white so that
+	// the paint can be blended over the glyphs.
+	color := r.color
+	if r.paint != nil {
+		color = White
+	}
+
+	col := color.AsHex()
 	for _, char := range message {
 		encoding := encode(col, plain, char)
 
 		glyph, ok := r.font.hasGlyph(encoding)
-		if !ok {
-			texture, dim := r.renderRune(r.color, char)
+		if ok {
+			r.stats.GlyphHits++
+		} else {
+			r.stats.GlyphMisses++
+			texture, dim := r.renderRune(color, char)
 			glyph = r.font.cache(encoding, texture, dim)
 		}
 
 		dim := glyph.dim
 
-		r.Renderer.Copy(glyph.tex, nil, &sdl.Rect{int32(x) + width, int32(y), dim[0], dim[1]})
+		if r.paint != nil {
+			r.paintTexture(glyph.tex, float64(int32(x)+width), float64(y), float64(dim[0]), float64(dim[1]))
+		} else {
+			r.prepareTexture(glyph.tex, r.blend, White.ToSDLColor())
+			r.copy(glyph.tex, nil, float64(int32(x)+width), float64(y), float64(dim[0]), float64(dim[1]))
+		}
 		width += dim[0]
 		height = maxInt32(height, dim[1])
 	}
@@ -214,12 +346,17 @@ func (r *Renderer) UncachedText(message string, x, y int) (int, int) {
 		panic("Attempted to render '" + message + "' but no font is set!")
 	}
 
+	color := r.color
+	if r.paint != nil {
+		color = White
+	}
+
 	var surface *sdl.Surface
 	var err error
 	if r.Alias {
-		surface, err = r.font.RenderUTF8Blended(message, r.color.ToSDLColor())
+		surface, err = r.font.RenderUTF8Blended(message, color.ToSDLColor())
 	} else {
-		surface, err = r.font.RenderUTF8Solid(message, r.color.ToSDLColor())
+		surface, err = r.font.RenderUTF8Solid(message, color.ToSDLColor())
 	}
 	defer surface.Free()
 	if err != nil {
@@ -231,8 +368,14 @@ func (r *Renderer) UncachedText(message string, x, y int) (int, int) {
 	if err != nil {
 		panic(err)
 	}
+	r.textureAllocated()
 
-	r.Renderer.Copy(texture, nil, &sdl.Rect{int32(x), int32(y), surface.W, surface.H})
+	if r.paint != nil {
+		r.paintTexture(texture, float64(x), float64(y), float64(surface.W), float64(surface.H))
+	} else {
+		r.prepareTexture(texture, r.blend, White.ToSDLColor())
+		r.copy(texture, nil, float64(x), float64(y), float64(surface.W), float64(surface.H))
+	}
 	return int(surface.W), int(surface.H)
 }
 
@@ -246,9 +389,9 @@ func (r *Renderer) SubImage(image *Image, x, y int, tx, ty, tw, th int) {
 // SubImageScale will render a sub-section of the given image scaled
 // to the given width and height. See the documentation for SubImage.
 func (r *Renderer) SubImageScale(image *Image, x, y int, tx, ty, tw, th int, sw, sh int) {
-	r.Copy(image.Texture, &sdl.Rect{
+	r.drawImage(image, &sdl.Rect{
 		int32(tx), int32(ty), int32(tw), int32(th),
-	}, &sdl.Rect{int32(x), int32(y), int32(sw), int32(sh)})
+	}, r.transform.Multiply(Translation(float64(x), float64(y))), float64(sw), float64(sh), nil)
 }
 
 // Image will render the given image at the given
@@ -264,7 +407,63 @@ func (r *Renderer) Image(image *Image, x, y int) {
 // ImageScale will render the image at the given co-ordinate
 // scaled to the given size.
 func (r *Renderer) ImageScale(image *Image, x, y, w, h int) {
-	r.Copy(image.Texture, nil, &sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+	r.drawImage(image, nil, r.transform.Multiply(Translation(float64(x), float64(y))), float64(w), float64(h), nil)
+}
+
+// DrawOptions controls how an image is drawn with DrawImage.
+// X, Y => Where the origin of the image is drawn;
+// ScaleX, ScaleY => How much to scale the image by, zero is treated as 1;
+// Rotation => How far to rotate the image clockwise in degrees;
+// Origin => The point in the image, in pixels from its top left,
+// that is placed at X, Y and that the image is scaled and rotated around;
+// FlipH, FlipV => Mirror the image horizontally or vertically;
+// Source => The part of the image to draw, nil for the whole image; and
+// Tint => A colour that the image is multiplied by, nil for none. Its
+// alpha fades the image, e.g. &Color{255, 255, 255, 128} draws the
+// image at half opacity.
+type DrawOptions struct {
+	X, Y           float64
+	ScaleX, ScaleY float64
+	Rotation       float64
+	Origin         Point
+	FlipH, FlipV   bool
+	Source         *Rectangle
+	Tint           *Color
+}
+
+// DrawImage will render the given image with the given options,
+// see DrawOptions.
+func (r *Renderer) DrawImage(image *Image, opts DrawOptions) {
+	var src *sdl.Rect
+	w, h := float64(image.Width), float64(image.Height)
+	if opts.Source != nil {
+		src = &sdl.Rect{int32(opts.Source.X), int32(opts.Source.Y), int32(opts.Source.W), int32(opts.Source.H)}
+		w, h = float64(opts.Source.W), float64(opts.Source.H)
+	}
+
+	sx, sy := opts.ScaleX, opts.ScaleY
+	if sx == 0 {
+		sx = 1
+	}
+	if sy == 0 {
+		sy = 1
+	}
+
+	m := r.transform.
+		Multiply(Translation(opts.X, opts.Y)).
+		Multiply(Rotation(opts.Rotation)).
+		Multiply(Scaling(sx, sy)).
+		Multiply(Translation(-opts.Origin.X, -opts.Origin.Y))
+
+	// mirroring flips the image within its own rectangle.
+	if opts.FlipH {
+		m = m.Multiply(Translation(w, 0)).Multiply(Scaling(-1, 1))
+	}
+	if opts.FlipV {
+		m = m.Multiply(Translation(0, h)).Multiply(Scaling(1, -1))
+	}
+
+	r.drawImage(image, src, m, w, h, opts.Tint)
 }
 
 // CreateRenderer will create a rendering instance for the given
@@ -275,12 +474,12 @@ func (r *Renderer) ImageScale(image *Image, x, y, w, h int) {
 // during the creation.
 func CreateRenderer(parent *RenderWindow, config *RenderConfig) (*Renderer, error) {
 	var mode uint32
-	if config.Accelerated {
+	if config.Accelerated && !config.Headless {
 		mode |= sdl.RENDERER_ACCELERATED
 	} else {
 		mode |= sdl.RENDERER_SOFTWARE
 	}
-	if config.VerticalSync {
+	if config.VerticalSync && !config.Headless {
 		mode |= sdl.RENDERER_PRESENTVSYNC
 	}
 
@@ -333,13 +532,14 @@ func CreateRenderer(parent *RenderWindow, config *RenderConfig) (*Renderer, erro
 	fontPath := filepath.Join(fontFolder, chosenFont)
 	log.Println("Loading font ", fontPath)
 
-	renderInst.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
-
 	renderer := &Renderer{
 		RenderConfig: *config,
 		Renderer:     renderInst,
 		color:        RGB(255, 255, 255),
+		transform:    Identity(),
+		alpha:        255,
 	}
+	renderer.SetBlendMode(BlendAlpha)
 
 	// load a default font to render with.
 	defaultFont, err := LoadFont(fontPath, 24)
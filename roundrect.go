@@ -0,0 +1,85 @@
+package strife
+
+import (
+	"math"
+)
+
+// Corners holds a value for each corner of
+// a rectangle, e.g. the radius of a rounded rectangle.
+type Corners struct {
+	TopLeft, TopRight, BottomRight, BottomLeft int
+}
+
+// RoundedRect will draw a rectangle at the given x, y co-ordinates
+// of the specified size with each corner rounded off by the given
+// radius. It takes the mode to render the rectangle as: fill or line.
+// Filled rectangles are anti-aliased if Alias is set in the config.
+func (r *Renderer) RoundedRect(x, y, w, h, radius int, mode Style) {
+	r.RoundedRectCorners(x, y, w, h, Corners{radius, radius, radius, radius}, mode)
+}
+
+// RoundedRectCorners is the same as RoundedRect, but each
+// corner can have its own radius.
+func (r *Renderer) RoundedRectCorners(x, y, w, h int, radii Corners, mode Style) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	points := roundedRectOutline(float64(x), float64(y), float64(w), float64(h), radii)
+	if mode == Line {
+		r.Polygon(points, Line)
+		return
+	}
+	r.fillConvex(points, r.Alias)
+}
+
+// roundedRectOutline returns the outline of a rounded rectangle
+// winding clockwise from the top left corner. If the radii don't
+// fit in the rectangle they are all scaled down until they do.
+func roundedRectOutline(x, y, w, h float64, radii Corners) []Point {
+	tl := math.Max(float64(radii.TopLeft), 0)
+	tr := math.Max(float64(radii.TopRight), 0)
+	br := math.Max(float64(radii.BottomRight), 0)
+	bl := math.Max(float64(radii.BottomLeft), 0)
+
+	scale := 1.0
+	for _, fit := range [][2]float64{
+		{w, tl + tr}, {w, bl + br}, {h, tl + bl}, {h, tr + br},
+	} {
+		if fit[1] > 0 {
+			scale = math.Min(scale, fit[0]/fit[1])
+		}
+	}
+	tl, tr, br, bl = tl*scale, tr*scale, br*scale, bl*scale
+
+	var points []Point
+	corner := func(cx, cy, radius, start float64) {
+		if radius <= 0 {
+			points = append(points, Point{cx, cy})
+			return
+		}
+		n := arcSegments(radius, math.Pi/2)
+		for i := 0; i <= n; i++ {
+			t := start + (math.Pi/2)*float64(i)/float64(n)
+			points = append(points, Point{cx + math.Cos(t)*radius, cy + math.Sin(t)*radius})
+		}
+	}
+
+	corner(x+tl, y+tl, tl, math.Pi)
+	corner(x+w-tr, y+tr, tr, 3*math.Pi/2)
+	corner(x+w-br, y+h-br, br, 0)
+	corner(x+bl, y+h-bl, bl, math.Pi/2)
+
+	// neighbouring corners that touch share a point.
+	result := points[:0]
+	for i, p := range points {
+		if i > 0 && p.distance(result[len(result)-1]) < 1e-9 {
+			continue
+		}
+		result = append(result, p)
+	}
+	if len(result) > 1 && result[0].distance(result[len(result)-1]) < 1e-9 {
+		result = result[:len(result)-1]
+	}
+	return result
+}
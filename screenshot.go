@@ -0,0 +1,68 @@
+package strife
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Screenshot reads back everything that has been drawn. It must be
+// called after the frame is drawn but before Display, as what is in
+// the window is undefined once it has been displayed. If a canvas is
+// set as the target then the canvas is read instead, its colours
+// are already premultiplied by their alpha as image.RGBA expects.
+func (r *Renderer) Screenshot() (*image.RGBA, error) {
+	w, h := r.GetSize()
+	return r.ScreenshotRegion(Rectangle{0, 0, w, h})
+}
+
+// ScreenshotRegion reads back the given area of what has been drawn,
+// see Screenshot. The area is in screen co-ordinates and is cut down
+// to fit inside of the screen.
+func (r *Renderer) ScreenshotRegion(area Rectangle) (*image.RGBA, error) {
+	w, h := r.GetSize()
+	if w < 0 || h < 0 {
+		return nil, fmt.Errorf("Failed to get the size of the renderer")
+	}
+
+	rect := intersectRects(
+		&sdl.Rect{int32(area.X), int32(area.Y), int32(area.W), int32(area.H)},
+		&sdl.Rect{0, 0, int32(w), int32(h)},
+	)
+	if rect.W == 0 || rect.H == 0 {
+		return nil, fmt.Errorf("Screenshot area is empty")
+	}
+
+	result := image.NewRGBA(image.Rect(0, 0, int(rect.W), int(rect.H)))
+	if err := r.ReadPixels(rect, uint32(sdl.PIXELFORMAT_RGBA32), unsafe.Pointer(&result.Pix[0]), result.Stride); err != nil {
+		return nil, fmt.Errorf("Failed to read pixels: %s", err)
+	}
+
+	// the alpha of the window is meaningless and is often
+	// zero, so the screenshot is made opaque.
+	if r.target == nil {
+		for i := 3; i < len(result.Pix); i += 4 {
+			result.Pix[i] = 255
+		}
+	}
+	return result, nil
+}
+
+// SaveScreenshot takes a screenshot and saves it as a PNG
+// at the given path, see Screenshot.
+func (r *Renderer) SaveScreenshot(path string) error {
+	shot, err := r.Screenshot()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, shot); err != nil {
+		return fmt.Errorf("Failed to save screenshot '%s': %s", path, err)
+	}
+	return writeFile(path, buf.Bytes())
+}
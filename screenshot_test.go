@@ -0,0 +1,37 @@
+package strife_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/strifetest"
+)
+
+func TestScreenshotCanvas(t *testing.T) {
+	window := strifetest.NewWindow(t, 4, 4)
+	ctx := window.GetRenderContext()
+	canvas, err := strife.NewCanvas(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer canvas.Destroy()
+
+	if err := ctx.SetTarget(canvas); err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.ResetTarget()
+	ctx.SetColor(&strife.Color{R: 255, G: 255, B: 255, A: 128})
+	ctx.Rect(0, 0, 2, 4, strife.Fill)
+
+	shot, err := ctx.Screenshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := shot.RGBAAt(0, 0), (color.RGBA{128, 128, 128, 128}); got != want {
+		t.Errorf("translucent pixel is %v, want %v", got, want)
+	}
+	if got := shot.RGBAAt(3, 0); got != (color.RGBA{}) {
+		t.Errorf("empty pixel is %v, want transparent", got)
+	}
+}
@@ -0,0 +1,257 @@
+package strife
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Circle will draw a circle centred on the given x, y co-ordinates
+// with the given radius. It takes the mode to render the
+// circle as: fill or line.
+func (r *Renderer) Circle(x, y, radius int, mode Style) {
+	r.Ellipse(x, y, radius, radius, mode)
+}
+
+// Ellipse will draw an ellipse centred on the given x, y co-ordinates
+// with the horizontal radius rx and the vertical radius ry.
+func (r *Renderer) Ellipse(x, y, rx, ry int, mode Style) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+
+	if _, _, ok := r.offset(); !ok {
+		r.smoothArc(float64(x), float64(y), float64(rx), float64(ry), 0, 360, mode)
+		return
+	}
+
+	outline := ellipseOutline(rx, ry)
+	if mode == Line {
+		r.drawPoints(offsetPoints(outline, x, y))
+		return
+	}
+
+	spans := outlineSpans(outline)
+	rects := make([]sdl.Rect, 0, len(spans))
+	for _, s := range spans {
+		rects = append(rects, sdl.Rect{int32(x + s.x0), int32(y + s.y), int32(s.x1 - s.x0 + 1), 1})
+	}
+	r.fillRects(rects)
+}
+
+// Arc will draw the part of a circle centred on x, y that lies between
+// the angles start and end. Angles are in degrees and run clockwise
+// from the positive x axis, the same as the rotation used by SDL.
+// Drawing an arc with the Fill style will fill in the pie slice.
+func (r *Renderer) Arc(x, y, radius int, start, end float64, mode Style) {
+	if radius < 0 {
+		return
+	}
+
+	if _, _, ok := r.offset(); !ok {
+		r.smoothArc(float64(x), float64(y), float64(radius), float64(radius), start, end, mode)
+		return
+	}
+
+	outline := ellipseOutline(radius, radius)
+	if mode == Line {
+		points := make([]sdl.Point, 0, len(outline))
+		for _, p := range outline {
+			if inArc(int(p.X), int(p.Y), start, end) {
+				points = append(points, p)
+			}
+		}
+		r.drawPoints(offsetPoints(points, x, y))
+		return
+	}
+
+	var rects []sdl.Rect
+	for _, s := range outlineSpans(outline) {
+		// split each span of the circle into runs of pixels
+		// that fall within the arc.
+		runStart, running := 0, false
+		for px := s.x0; px <= s.x1+1; px++ {
+			inside := px <= s.x1 && inArc(px, s.y, start, end)
+			if inside && !running {
+				runStart, running = px, true
+			} else if !inside && running {
+				rects = append(rects, sdl.Rect{int32(x + runStart), int32(y + s.y), int32(px - runStart), 1})
+				running = false
+			}
+		}
+	}
+	if len(rects) > 0 {
+		r.fillRects(rects)
+	}
+}
+
+// smoothArc draws an elliptical arc made of line segments. This
+// is used instead of drawing pixels when the transform scales
+// or rotates, as the pixels would not line up with the screen.
+func (r *Renderer) smoothArc(x, y, rx, ry, start, end float64, mode Style) {
+	sweep := end - start
+	full := sweep >= 360 || sweep <= -360
+	if full {
+		sweep = 360
+	} else {
+		sweep = math.Mod(sweep+360, 360)
+	}
+
+	radius := math.Max(rx, ry) * r.transform.scale()
+	n := arcSegments(radius, sweep*math.Pi/180)
+	if full {
+		n = int(math.Max(float64(n), 8))
+	}
+
+	points := make([]Point, 0, n+2)
+	for i := 0; i <= n; i++ {
+		if full && i == n {
+			break
+		}
+		t := (start + sweep*float64(i)/float64(n)) * math.Pi / 180
+		points = append(points, Point{x + math.Cos(t)*rx, y + math.Sin(t)*ry})
+	}
+	if !full && mode == Fill {
+		points = append(points, Point{x, y})
+	}
+
+	if mode == Fill {
+		if full {
+			r.fillConvex(points, false)
+		} else {
+			r.fillTriangles(triangulate(points))
+		}
+		return
+	}
+
+	lines := make([]sdl.FPoint, 0, len(points)+1)
+	for _, p := range points {
+		lines = append(lines, sdl.FPoint{float32(p.X), float32(p.Y)})
+	}
+	if full {
+		lines = append(lines, lines[0])
+	}
+	r.drawLines(lines)
+}
+
+// span is a horizontal run of pixels on row y
+// from x0 to x1 inclusive.
+type span struct {
+	y, x0, x1 int
+}
+
+// inArc checks if the pixel at the offset dx, dy from the
+// centre of a circle falls between the angles start and end.
+func inArc(dx, dy int, start, end float64) bool {
+	sweep := end - start
+	if sweep >= 360 || sweep <= -360 {
+		return true
+	}
+	if dx == 0 && dy == 0 {
+		return true
+	}
+
+	sweep = math.Mod(sweep+360, 360)
+	angle := math.Atan2(float64(dy), float64(dx)) * 180 / math.Pi
+	return math.Mod(angle-start+720, 360) <= sweep
+}
+
+// ellipseOutline will compute the outline of an ellipse
+// centred on 0, 0 using the midpoint ellipse algorithm. Each
+// pixel is only returned once.
+func ellipseOutline(rx, ry int) []sdl.Point {
+	seen := map[sdl.Point]bool{}
+	var points []sdl.Point
+
+	plot := func(x, y int) {
+		for _, p := range []sdl.Point{
+			{int32(x), int32(y)}, {int32(-x), int32(y)},
+			{int32(x), int32(-y)}, {int32(-x), int32(-y)},
+		} {
+			if !seen[p] {
+				seen[p] = true
+				points = append(points, p)
+			}
+		}
+	}
+
+	if rx == 0 || ry == 0 {
+		for x := -rx; x <= rx; x++ {
+			for y := -ry; y <= ry; y++ {
+				plot(x, y)
+			}
+		}
+		return points
+	}
+
+	rx2, ry2 := int64(rx)*int64(rx), int64(ry)*int64(ry)
+	x, y := int64(0), int64(ry)
+
+	// region one, where the slope of the curve is shallow.
+	dx, dy := int64(0), 2*rx2*y
+	d := ry2 - rx2*int64(ry) + rx2/4
+	for dx < dy {
+		plot(int(x), int(y))
+		x++
+		dx += 2 * ry2
+		if d < 0 {
+			d += dx + ry2
+		} else {
+			y--
+			dy -= 2 * rx2
+			d += dx - dy + ry2
+		}
+	}
+
+	// region two, where the slope of the curve is steep.
+	d = ry2*(2*x+1)*(2*x+1)/4 + rx2*(y-1)*(y-1) - rx2*ry2
+	for y >= 0 {
+		plot(int(x), int(y))
+		y--
+		dy -= 2 * rx2
+		if d > 0 {
+			d += rx2 - dy
+		} else {
+			x++
+			dx += 2 * ry2
+			d += dx - dy + rx2
+		}
+	}
+
+	return points
+}
+
+// outlineSpans will turn a closed outline into the horizontal
+// spans that are needed to fill it.
+func outlineSpans(outline []sdl.Point) []span {
+	rows := map[int32]*span{}
+	var order []int32
+	for _, p := range outline {
+		s, ok := rows[p.Y]
+		if !ok {
+			rows[p.Y] = &span{int(p.Y), int(p.X), int(p.X)}
+			order = append(order, p.Y)
+			continue
+		}
+		if int(p.X) < s.x0 {
+			s.x0 = int(p.X)
+		}
+		if int(p.X) > s.x1 {
+			s.x1 = int(p.X)
+		}
+	}
+
+	spans := make([]span, 0, len(order))
+	for _, y := range order {
+		spans = append(spans, *rows[y])
+	}
+	return spans
+}
+
+func offsetPoints(points []sdl.Point, x, y int) []sdl.Point {
+	result := make([]sdl.Point, len(points))
+	for i, p := range points {
+		result[i] = sdl.Point{p.X + int32(x), p.Y + int32(y)}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package strife_test
+
+import (
+	"testing"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/strifetest"
+)
+
+func TestShapePixels(t *testing.T) {
+	// each shape is drawn centred on 8, 8 and the
+	// rows show the pixels from 4, 4 to 12, 12.
+	const cx, cy, size = 8, 8, 17
+	tests := []struct {
+		name string
+		draw func(r *strife.Renderer)
+		want []string
+	}{
+		{"circle fill", func(r *strife.Renderer) { r.Circle(cx, cy, 3, strife.Fill) }, []string{
+			".........",
+			"...###...",
+			"..#####..",
+			".#######.",
+			".#######.",
+			".#######.",
+			"..#####..",
+			"...###...",
+			".........",
+		}},
+		{"circle line", func(r *strife.Renderer) { r.Circle(cx, cy, 3, strife.Line) }, []string{
+			".........",
+			"...###...",
+			"..#...#..",
+			".#.....#.",
+			".#.....#.",
+			".#.....#.",
+			"..#...#..",
+			"...###...",
+			".........",
+		}},
+		{"ellipse fill", func(r *strife.Renderer) { r.Ellipse(cx, cy, 4, 2, strife.Fill) }, []string{
+			".........",
+			".........",
+			"..#####..",
+			".#######.",
+			"#########",
+			".#######.",
+			"..#####..",
+			".........",
+			".........",
+		}},
+		{"ellipse line", func(r *strife.Renderer) { r.Ellipse(cx, cy, 4, 2, strife.Line) }, []string{
+			".........",
+			".........",
+			"..#####..",
+			".#.....#.",
+			"#.......#",
+			".#.....#.",
+			"..#####..",
+			".........",
+			".........",
+		}},
+		{"arc fill", func(r *strife.Renderer) { r.Arc(cx, cy, 4, 0, 90, strife.Fill) }, []string{
+			".........",
+			".........",
+			".........",
+			".........",
+			"....#####",
+			"....#####",
+			"....####.",
+			"....####.",
+			"....##...",
+		}},
+		{"arc line", func(r *strife.Renderer) { r.Arc(cx, cy, 4, 0, 90, strife.Line) }, []string{
+			".........",
+			".........",
+			".........",
+			".........",
+			"........#",
+			"........#",
+			".......#.",
+			"......##.",
+			"....##...",
+		}},
+		{"arc fill top half", func(r *strife.Renderer) { r.Arc(cx, cy, 4, 180, 360, strife.Fill) }, []string{
+			"...###...",
+			".#######.",
+			".#######.",
+			"#########",
+			"#########",
+			".........",
+			".........",
+			".........",
+			".........",
+		}},
+		{"arc line top half", func(r *strife.Renderer) { r.Arc(cx, cy, 4, 180, 360, strife.Line) }, []string{
+			"...###...",
+			".##...##.",
+			".#.....#.",
+			"#.......#",
+			"#.......#",
+			".........",
+			".........",
+			".........",
+			".........",
+		}},
+	}
+
+	window := strifetest.NewWindow(t, size, size)
+	ctx := window.GetRenderContext()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx.Clear()
+			test.draw(ctx)
+			shot, err := ctx.Screenshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for y := 0; y < size; y++ {
+				for x := 0; x < size; x++ {
+					ax, ay := x-cx+4, y-cy+4
+					want := ay >= 0 && ay < len(test.want) && ax >= 0 && ax < len(test.want[ay]) && test.want[ay][ax] == '#'
+					c := shot.RGBAAt(x, y)
+					lit := c.R == 255 && c.G == 255 && c.B == 255
+					if !lit && (c.R != 0 || c.G != 0 || c.B != 0) {
+						t.Errorf("pixel (%d, %d) is %v, want black or white", x, y, c)
+					} else if lit != want {
+						t.Errorf("pixel (%d, %d) lit is %t, want %t", x, y, lit, want)
+					}
+				}
+			}
+		})
+	}
+}
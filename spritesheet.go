@@ -0,0 +1,253 @@
+package strife
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// DefaultFrameDuration is how long each frame of a sprite
+// sheet is shown for when no duration is given.
+const DefaultFrameDuration = 100 * time.Millisecond
+
+// Frame is one frame of a sprite sheet, the area X, Y, W, H
+// of the sheets image that is shown for the given Duration.
+// Tools like TexturePacker can trim the transparent edges off
+// of a frame, and can rotate it to pack it more tightly.
+// OffsetX, OffsetY => Where the trimmed frame sits inside of
+// the frame before it was trimmed;
+// SourceW, SourceH => The size of the frame before it was trimmed; and
+// Rotated => The frame is stored in the image turned 90 degrees
+// clockwise, so it covers H by W pixels from X, Y.
+type Frame struct {
+	Name       string
+	X, Y, W, H int
+	Duration   time.Duration
+
+	OffsetX, OffsetY int
+	SourceW, SourceH int
+	Rotated          bool
+}
+
+// FrameTag is a named sequence of frames in a sprite
+// sheet, e.g. a walk cycle, and how it should be played.
+type FrameTag struct {
+	Frames []int
+	Mode   AnimationMode
+}
+
+// SpriteSheet is an image that has been sliced up into frames,
+// either as a grid or from the JSON that a tool like TexturePacker
+// or Aseprite exports.
+type SpriteSheet struct {
+	Image  *Image
+	Frames []Frame
+	Tags   map[string]FrameTag
+}
+
+// NewSpriteSheet slices the given image into a grid of frames
+// of the given size, going left to right and then top to bottom.
+func NewSpriteSheet(image *Image, frameWidth, frameHeight int) *SpriteSheet {
+	sheet := &SpriteSheet{Image: image, Tags: map[string]FrameTag{}}
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return sheet
+	}
+
+	for y := 0; y+frameHeight <= image.Height; y += frameHeight {
+		for x := 0; x+frameWidth <= image.Width; x += frameWidth {
+			sheet.Frames = append(sheet.Frames, Frame{
+				Name:     fmt.Sprintf("%d", len(sheet.Frames)),
+				X:        x,
+				Y:        y,
+				W:        frameWidth,
+				H:        frameHeight,
+				Duration: DefaultFrameDuration,
+				SourceW:  frameWidth,
+				SourceH:  frameHeight,
+			})
+		}
+	}
+	return sheet
+}
+
+// LoadSpriteSheet slices the given image using the JSON at the
+// given path, see ParseSpriteSheet.
+func LoadSpriteSheet(image *Image, path string) (*SpriteSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load sprite sheet '%s'", path)
+	}
+	return ParseSpriteSheet(image, data)
+}
+
+type sheetRect struct {
+	X, Y, W, H int
+}
+
+type sheetFrame struct {
+	Filename         string    `json:"filename"`
+	Frame            sheetRect `json:"frame"`
+	Duration         *int      `json:"duration"`
+	Rotated          bool      `json:"rotated"`
+	Trimmed          bool      `json:"trimmed"`
+	SpriteSourceSize sheetRect `json:"spriteSourceSize"`
+	SourceSize       sheetRect `json:"sourceSize"`
+}
+
+type sheetJSON struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"`
+		} `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// ParseSpriteSheet slices the given image using the JSON data that
+// TexturePacker or Aseprite exports, in either their hash or array
+// format. Frame durations and tags from Aseprite are kept, as are
+// frames that TexturePacker has trimmed or rotated.
+func ParseSpriteSheet(image *Image, data []byte) (*SpriteSheet, error) {
+	var doc sheetJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Failed to parse sprite sheet: %s", err)
+	}
+
+	frames, err := parseSheetFrames(doc.Frames)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &SpriteSheet{Image: image, Tags: map[string]FrameTag{}}
+	for _, f := range frames {
+		duration := DefaultFrameDuration
+		if f.Duration != nil {
+			duration = time.Duration(*f.Duration) * time.Millisecond
+		}
+		frame := Frame{
+			Name:     f.Filename,
+			X:        f.Frame.X,
+			Y:        f.Frame.Y,
+			W:        f.Frame.W,
+			H:        f.Frame.H,
+			Duration: duration,
+			SourceW:  f.Frame.W,
+			SourceH:  f.Frame.H,
+			Rotated:  f.Rotated,
+		}
+		if f.Trimmed {
+			frame.OffsetX, frame.OffsetY = f.SpriteSourceSize.X, f.SpriteSourceSize.Y
+			frame.SourceW, frame.SourceH = f.SourceSize.W, f.SourceSize.H
+		}
+		sheet.Frames = append(sheet.Frames, frame)
+	}
+
+	for _, tag := range doc.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(sheet.Frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("Frame tag '%s' is out of range", tag.Name)
+		}
+
+		var indices []int
+		for i := tag.From; i <= tag.To; i++ {
+			indices = append(indices, i)
+		}
+
+		mode := Loop
+		switch tag.Direction {
+		case "reverse":
+			for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+				indices[i], indices[j] = indices[j], indices[i]
+			}
+		case "pingpong":
+			mode = PingPong
+		}
+		sheet.Tags[tag.Name] = FrameTag{indices, mode}
+	}
+
+	return sheet, nil
+}
+
+// parseSheetFrames reads the frames as an array, or as an object
+// of frames by name. The object is read a key at a time because
+// the order of the frames matters.
+func parseSheetFrames(data json.RawMessage) ([]sheetFrame, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Sprite sheet has no frames")
+	}
+
+	var frames []sheetFrame
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &frames); err != nil {
+			return nil, fmt.Errorf("Failed to parse sprite sheet frames: %s", err)
+		}
+		return frames, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("Failed to parse sprite sheet frames: %s", err)
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse sprite sheet frames: %s", err)
+		}
+
+		var frame sheetFrame
+		if err := dec.Decode(&frame); err != nil {
+			return nil, fmt.Errorf("Failed to parse sprite sheet frame '%v': %s", key, err)
+		}
+		frame.Filename, _ = key.(string)
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// FrameIndex returns the index of the frame with the given name.
+func (s *SpriteSheet) FrameIndex(name string) (int, bool) {
+	for i, frame := range s.Frames {
+		if frame.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Animation creates an animation that plays the frames of the
+// tag with the given name.
+func (s *SpriteSheet) Animation(tag string) (*Animation, error) {
+	t, ok := s.Tags[tag]
+	if !ok {
+		return nil, fmt.Errorf("Sprite sheet has no tag called '%s'", tag)
+	}
+	return NewAnimation(s, t.Mode, t.Frames...), nil
+}
+
+// Frame will render the frame at the given index of the
+// sprite sheet at the given x, y co-ordinates. Trimmed frames
+// are drawn where they were before they were trimmed, and
+// rotated frames are turned back the right way up.
+func (r *Renderer) Frame(sheet *SpriteSheet, index, x, y int) {
+	f := sheet.Frames[index]
+	x, y = x+f.OffsetX, y+f.OffsetY
+	if !f.Rotated {
+		r.SubImage(sheet.Image, x, y, f.X, f.Y, f.W, f.H)
+		return
+	}
+
+	// turn the H by W area of the image 90 degrees
+	// anticlockwise so its top left is at x, y.
+	m := r.transform.
+		Multiply(Translation(float64(x), float64(y))).
+		Multiply(Matrix{A: 0, B: -1, C: 1, D: 0, F: float64(f.H)})
+	src := &sdl.Rect{int32(f.X), int32(f.Y), int32(f.H), int32(f.W)}
+	r.drawImage(sheet.Image, src, m, float64(f.H), float64(f.W), nil)
+}
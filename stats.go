@@ -0,0 +1,143 @@
+package strife
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// statsHistory is how many frames the
+// stats overlay graphs.
+const statsHistory = 120
+
+// FrameStats are counts of what the renderer did
+// during a frame, to help find out why a frame is slow.
+// DrawCalls => How many times something was sent to SDL to draw;
+// TextureSwitches => How many times the texture being drawn with
+// changed, drawing without a texture counts as a texture;
+// GlyphHits, GlyphMisses => How many glyphs of text were found in
+// the glyph cache, and how many had to be rendered;
+// TextureAllocs => How many textures were created; and
+// FrameTime => How long it was since the frame before was displayed.
+type FrameStats struct {
+	DrawCalls       int
+	TextureSwitches int
+	GlyphHits       int
+	GlyphMisses     int
+	TextureAllocs   int
+	FrameTime       time.Duration
+}
+
+// Stats returns the stats of the last frame that was displayed
+func (r *Renderer) Stats() FrameStats {
+	return r.lastStats
+}
+
+// ShowStats turns the stats overlay on or off. The overlay
+// draws a graph of the frame times and the stats of the last
+// frame in the top left corner of the screen.
+func (r *Renderer) ShowStats(show bool) {
+	r.showStats = show
+}
+
+// countDraw records a draw call using the given
+// texture, which is nil for untextured drawing.
+func (r *Renderer) countDraw(texture *sdl.Texture) {
+	r.stats.DrawCalls++
+	if r.stats.DrawCalls == 1 || texture != r.lastTexture {
+		r.stats.TextureSwitches++
+	}
+	r.lastTexture = texture
+}
+
+// textureAllocated records that a texture was created
+func (r *Renderer) textureAllocated() {
+	r.stats.TextureAllocs++
+}
+
+// endFrame finishes off the stats of the frame that
+// is about to be displayed.
+func (r *Renderer) endFrame() {
+	now := time.Now()
+	if !r.frameStart.IsZero() {
+		r.stats.FrameTime = now.Sub(r.frameStart)
+	}
+	r.frameStart = now
+
+	r.lastStats = r.stats
+	r.frameTimes = append(r.frameTimes, r.stats.FrameTime)
+	if len(r.frameTimes) > statsHistory {
+		r.frameTimes = r.frameTimes[len(r.frameTimes)-statsHistory:]
+	}
+}
+
+// startFrame clears the stats for the next frame
+func (r *Renderer) startFrame() {
+	r.stats = FrameStats{}
+	r.lastTexture = nil
+}
+
+// drawStats draws the stats overlay, without any of the
+// current transform, clip, colour or paint.
+func (r *Renderer) drawStats() {
+	color, paint, tint, alpha, blend := r.color, r.paint, r.tint, r.alpha, r.blend
+	r.Push()
+	r.ResetTransform()
+	clips := r.clips
+	r.clips = nil
+	r.applyClip()
+	r.SetTint(nil)
+	r.SetAlpha(255)
+	r.SetBlendMode(BlendAlpha)
+
+	const barWidth, graphHeight = 2, 60
+	const x, y = 8, 8
+	width := statsHistory * barWidth
+
+	r.SetColor(&Color{0, 0, 0, 180})
+	r.Rect(x, y, width, graphHeight, Fill)
+
+	// a bar for each frame, 33ms reaches the top of
+	// the graph, with a line at 60fps.
+	for i, frameTime := range r.frameTimes {
+		ms := frameTime.Seconds() * 1000
+		switch {
+		case ms <= 1000.0/60+0.5:
+			r.SetColor(Green)
+		case ms <= 1000.0/30+0.5:
+			r.SetColor(RGB(255, 200, 0))
+		default:
+			r.SetColor(Red)
+		}
+		h := minInt(int(ms/(1000.0/30)*graphHeight), graphHeight)
+		r.Rect(x+i*barWidth, y+graphHeight-h, barWidth, h, Fill)
+	}
+	r.SetColor(&Color{255, 255, 255, 120})
+	r.Rect(x, y+graphHeight/2, width, 1, Fill)
+
+	if r.font != nil {
+		s := r.lastStats
+		lines := []string{
+			fmt.Sprintf("%.2fms", s.FrameTime.Seconds()*1000),
+			fmt.Sprintf("draws %d, switches %d", s.DrawCalls, s.TextureSwitches),
+			fmt.Sprintf("glyphs %d hit, %d missed", s.GlyphHits, s.GlyphMisses),
+			fmt.Sprintf("textures %d allocated", s.TextureAllocs),
+		}
+		ty := y + graphHeight + 4
+		for _, line := range lines {
+			r.SetColor(White)
+			_, h := r.Text(line, x, ty)
+			ty += h
+		}
+	}
+
+	r.Pop()
+	r.clips = clips
+	r.applyClip()
+	r.SetBlendMode(blend)
+	r.alpha = alpha
+	r.SetTint(tint)
+	r.SetColor(color)
+	r.paint = paint
+}
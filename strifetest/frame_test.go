@@ -0,0 +1,26 @@
+package strifetest_test
+
+import (
+	"testing"
+
+	"github.com/felixangell/strife"
+	"github.com/felixangell/strife/strifetest"
+)
+
+func TestFrame(t *testing.T) {
+	window := strifetest.NewWindow(t, 16, 16)
+	ctx := window.GetRenderContext()
+	ctx.Clear()
+
+	ctx.SetColor(strife.Red)
+	ctx.Rect(2, 2, 6, 4, strife.Fill)
+	ctx.SetColor(strife.Blue)
+	ctx.Rect(8, 6, 6, 8, strife.Line)
+
+	// a translucent rectangle over both, blending
+	// can round differently on each renderer.
+	ctx.SetColor(&strife.Color{R: 255, G: 255, B: 255, A: 128})
+	ctx.Rect(5, 4, 6, 4, strife.Fill)
+
+	strifetest.CheckFrame(t, ctx, "testdata/frame.png", strifetest.Tolerance{Channel: 2})
+}
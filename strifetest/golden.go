@@ -0,0 +1,164 @@
+package strifetest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixangell/strife"
+)
+
+// Tolerance is how different two images can be and still match.
+// Channel => How far apart each colour channel of a pixel can be,
+// from 0 to 255, before the pixel counts as different; and
+// Pixels => The fraction of pixels, from 0 to 1, that can be
+// different. The zero value means the images must be identical.
+type Tolerance struct {
+	Channel uint8
+	Pixels  float64
+}
+
+// Result is the outcome of comparing two images.
+// Different => How many pixels are further apart than the tolerance;
+// MaxDelta => The biggest difference of any channel of any pixel; and
+// Diff => An image with the different pixels in red.
+type Result struct {
+	Different int
+	MaxDelta  uint8
+	Diff      *image.RGBA
+}
+
+// Compare compares the image against the reference image
+// pixel by pixel. The images must be the same size.
+func Compare(got, want image.Image, tol Tolerance) (Result, error) {
+	gb, wb := got.Bounds(), want.Bounds()
+	if gb.Dx() != wb.Dx() || gb.Dy() != wb.Dy() {
+		return Result{}, fmt.Errorf("Image is %dx%d but should be %dx%d", gb.Dx(), gb.Dy(), wb.Dx(), wb.Dy())
+	}
+
+	result := Result{Diff: image.NewRGBA(image.Rect(0, 0, gb.Dx(), gb.Dy()))}
+	for y := 0; y < gb.Dy(); y++ {
+		for x := 0; x < gb.Dx(); x++ {
+			a := color.NRGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.NRGBA)
+			b := color.NRGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.NRGBA)
+
+			delta := maxDelta(a.R, b.R)
+			for _, d := range []uint8{maxDelta(a.G, b.G), maxDelta(a.B, b.B), maxDelta(a.A, b.A)} {
+				if d > delta {
+					delta = d
+				}
+			}
+			if delta > result.MaxDelta {
+				result.MaxDelta = delta
+			}
+
+			// the diff is a faded copy of the reference
+			// with the different pixels in red.
+			if delta > tol.Channel {
+				result.Different++
+				result.Diff.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				grey := uint8((uint16(b.R) + uint16(b.G) + uint16(b.B)) / 3 / 4)
+				result.Diff.Set(x, y, color.RGBA{grey, grey, grey, 255})
+			}
+		}
+	}
+	return result, nil
+}
+
+// Matches checks if the result is within the tolerance
+func (r Result) Matches(tol Tolerance) bool {
+	total := r.Diff.Bounds().Dx() * r.Diff.Bounds().Dy()
+	if total == 0 {
+		return true
+	}
+	return float64(r.Different)/float64(total) <= tol.Pixels
+}
+
+func maxDelta(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// LoadPNG loads the PNG image at the given path
+func LoadPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+// SavePNG saves the image as a PNG at the given path,
+// creating any directories that are needed.
+func SavePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// CheckGolden compares the image against the golden PNG at the
+// given path, failing the test if they don't match. When they
+// don't match the image and a diff are saved next to the golden
+// image as name.got.png and name.diff.png so they can be looked at.
+// If the UPDATE_GOLDEN environment variable is set the golden
+// image is replaced instead.
+func CheckGolden(t testing.TB, got image.Image, path string, tol Tolerance) {
+	t.Helper()
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := SavePNG(path, got); err != nil {
+			t.Fatalf("Failed to update golden image '%s': %s", path, err)
+		}
+		return
+	}
+
+	want, err := LoadPNG(path)
+	if err != nil {
+		t.Fatalf("Failed to load golden image '%s': %s, set UPDATE_GOLDEN=1 to create it", path, err)
+	}
+
+	result, err := Compare(got, want, tol)
+	if err != nil {
+		t.Fatalf("Golden image '%s': %s", path, err)
+	}
+	if result.Matches(tol) {
+		return
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	SavePNG(base+".got.png", got)
+	SavePNG(base+".diff.png", result.Diff)
+	t.Errorf("Image does not match golden image '%s', %d pixels are different by up to %d, see %s.diff.png",
+		path, result.Different, result.MaxDelta, base)
+}
+
+// CheckFrame takes a screenshot of what has been drawn and
+// compares it against the golden PNG at the given path, see
+// CheckGolden. It must be called before the frame is displayed.
+func CheckFrame(t testing.TB, r *strife.Renderer, path string, tol Tolerance) {
+	t.Helper()
+
+	shot, err := r.Screenshot()
+	if err != nil {
+		t.Fatalf("Failed to take screenshot: %s", err)
+	}
+	CheckGolden(t, shot, path, tol)
+}
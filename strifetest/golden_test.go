@@ -0,0 +1,89 @@
+package strifetest
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+func solid(w, h int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	grey := color.NRGBA{100, 100, 100, 255}
+	tests := []struct {
+		name      string
+		change    color.NRGBA
+		tol       Tolerance
+		different int
+		maxDelta  uint8
+		matches   bool
+	}{
+		{"identical", grey, Tolerance{}, 0, 0, true},
+		{"within channel", color.NRGBA{103, 98, 100, 255}, Tolerance{Channel: 3}, 0, 3, true},
+		{"past channel", color.NRGBA{104, 100, 100, 255}, Tolerance{Channel: 3}, 1, 4, false},
+		{"alpha counts", color.NRGBA{100, 100, 100, 200}, Tolerance{}, 1, 55, false},
+		{"within pixels", color.NRGBA{0, 0, 0, 255}, Tolerance{Pixels: 0.25}, 1, 100, true},
+		{"past pixels", color.NRGBA{0, 0, 0, 255}, Tolerance{Pixels: 0.2}, 1, 100, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want := solid(2, 2, grey)
+			got := solid(2, 2, grey)
+			got.SetNRGBA(1, 0, test.change)
+
+			result, err := Compare(got, want, test.tol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Different != test.different || result.MaxDelta != test.maxDelta {
+				t.Errorf("got %d different by up to %d, want %d by up to %d",
+					result.Different, result.MaxDelta, test.different, test.maxDelta)
+			}
+			if result.Matches(test.tol) != test.matches {
+				t.Errorf("Matches is %t, want %t", !test.matches, test.matches)
+			}
+
+			isRed := result.Diff.RGBAAt(1, 0) == color.RGBA{255, 0, 0, 255}
+			if isRed != (test.different > 0) {
+				t.Errorf("changed pixel in the diff is %v", result.Diff.RGBAAt(1, 0))
+			}
+			if result.Diff.RGBAAt(0, 0) == (color.RGBA{255, 0, 0, 255}) {
+				t.Error("unchanged pixel is red in the diff")
+			}
+		})
+	}
+}
+
+func TestCompareSize(t *testing.T) {
+	if _, err := Compare(solid(2, 2, color.NRGBA{}), solid(2, 3, color.NRGBA{}), Tolerance{}); err == nil {
+		t.Error("expected an error comparing images of different sizes")
+	}
+
+	// images only need to be the same size, not the same bounds
+	got := solid(4, 4, color.NRGBA{1, 2, 3, 255}).SubImage(image.Rect(2, 2, 4, 4))
+	result, err := Compare(got, solid(2, 2, color.NRGBA{1, 2, 3, 255}), Tolerance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Different != 0 {
+		t.Errorf("got %d different pixels, want none", result.Different)
+	}
+}
+
+func TestCheckGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.png")
+	img := solid(3, 2, color.NRGBA{10, 20, 30, 255})
+	if err := SavePNG(path, img); err != nil {
+		t.Fatal(err)
+	}
+	CheckGolden(t, img, path, Tolerance{})
+}
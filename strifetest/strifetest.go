@@ -0,0 +1,45 @@
+// Package strifetest helps with testing code that renders with
+// strife. It sets up headless windows that work without a display,
+// and compares rendered frames against golden reference images.
+//
+// A rendering test looks something like:
+//
+//	func TestBadge(t *testing.T) {
+//		window := strifetest.NewWindow(t, 320, 240)
+//		ctx := window.GetRenderContext()
+//		ctx.Clear()
+//		drawBadge(ctx)
+//		strifetest.CheckFrame(t, ctx, "testdata/badge.png", strifetest.Tolerance{})
+//	}
+//
+// Golden images are written, rather than compared, when the
+// UPDATE_GOLDEN environment variable is set.
+package strifetest
+
+import (
+	"testing"
+
+	"github.com/felixangell/strife"
+)
+
+// NewWindow creates a headless window of the given size, see
+// strife.HeadlessConfig. The test fails if the window can't
+// be created, and the window is closed when the test ends.
+func NewWindow(t testing.TB, w, h int) *strife.RenderWindow {
+	t.Helper()
+
+	config, err := strife.HeadlessConfig()
+	if err != nil {
+		t.Fatalf("Headless rendering is not available: %s", err)
+	}
+	window := strife.SetupRenderWindow(w, h, config)
+	if err := window.Create(); err != nil {
+		t.Fatalf("Failed to create headless window: %s", err)
+	}
+	t.Cleanup(func() {
+		if !window.CloseRequested() {
+			window.Close()
+		}
+	})
+	return window
+}
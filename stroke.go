@@ -0,0 +1,352 @@
+package strife
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// LineCap is the shape drawn at the open
+// ends of a line.
+type LineCap int
+
+// Types of line caps, ButtCap stops the line flat at
+// its end point, RoundCap adds a semi-circle and SquareCap
+// extends the line by half of its width.
+const (
+	ButtCap LineCap = iota
+	RoundCap
+	SquareCap
+)
+
+// LineJoin is the shape drawn where two
+// segments of a line meet.
+type LineJoin int
+
+// Types of line joins, MiterJoin extends the edges until
+// they meet, RoundJoin rounds off the corner and BevelJoin
+// cuts the corner off.
+const (
+	MiterJoin LineJoin = iota
+	RoundJoin
+	BevelJoin
+)
+
+// Stroke describes how lines are drawn.
+// Width => The thickness of the line in pixels, anything below 1 is drawn as 1;
+// Cap => The shape at the ends of the line;
+// Join => The shape of the corners; and
+// MiterLimit => How far a miter can extend as a multiple of the width
+// before it is bevelled instead, defaults to 4.
+type Stroke struct {
+	Width      float64
+	Cap        LineCap
+	Join       LineJoin
+	MiterLimit float64
+}
+
+// Line will draw a line from x1, y1 to x2, y2 with the
+// given stroke in the current colour.
+func (r *Renderer) Line(x1, y1, x2, y2 float64, stroke Stroke) {
+	r.Polyline([]Point{{x1, y1}, {x2, y2}}, stroke)
+}
+
+// Polyline will draw a line through each of the given points with
+// the given stroke in the current colour. The line is not closed.
+func (r *Renderer) Polyline(points []Point, stroke Stroke) {
+	r.fillStroke(strokeTriangles(points, stroke, false))
+}
+
+// fillStroke fills the triangles of a stroke. The segments, joins
+// and caps of a stroke overlap, so if the overlaps would be blended
+// more than once the stroke is drawn into a scratch canvas without
+// blending and then drawn from there in one go. BlendModulate can't
+// be drawn this way, as the empty parts of the canvas would darken
+// what is underneath, so modulated strokes are drawn directly.
+func (r *Renderer) fillStroke(tris []Point) {
+	if len(tris) == 0 || r.clippedAway() {
+		return
+	}
+	if !r.strokeOverlaps() || !r.RenderTargetSupported() {
+		r.fillTriangles(tris)
+		return
+	}
+
+	// the box around the stroke on screen, cut
+	// down to the part of it that can be seen.
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range tris {
+		x, y := r.transform.Apply(p.X, p.Y)
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+	w, h := r.GetSize()
+	area := intersectRects(&sdl.Rect{
+		int32(math.Floor(minX)), int32(math.Floor(minY)),
+		int32(math.Ceil(maxX) - math.Floor(minX)), int32(math.Ceil(maxY) - math.Floor(minY)),
+	}, &sdl.Rect{0, 0, int32(w), int32(h)})
+	if len(r.clips) > 0 {
+		area = intersectRects(area, r.clips[len(r.clips)-1])
+	}
+	if area.W <= 0 || area.H <= 0 {
+		return
+	}
+
+	scratch := r.scratchCanvas(int(area.W), int(area.H))
+	if scratch == nil {
+		r.fillTriangles(tris)
+		return
+	}
+	src := &sdl.Rect{0, 0, area.W, area.H}
+
+	// overwrite rather than blend so that the
+	// overlaps end up the same as everywhere else.
+	prev := r.GetRenderTarget()
+	r.SetRenderTarget(scratch.Texture)
+	r.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+	r.SetDrawColor(0, 0, 0, 0)
+	r.FillRect(src)
+
+	transform, clips := r.transform, r.clips
+	r.transform = Translation(-float64(area.X), -float64(area.Y)).Multiply(transform)
+	r.clips = nil
+	r.fillTriangles(tris)
+	r.transform, r.clips = transform, clips
+
+	r.SetRenderTarget(prev)
+	r.SetBlendMode(r.blend)
+	r.applyClip()
+	r.applyDrawColor()
+
+	setTextureBlend(scratch.Texture, r.blend)
+	scratch.Texture.SetColorMod(255, 255, 255)
+	scratch.Texture.SetAlphaMod(255)
+	r.Copy(scratch.Texture, src, area)
+	r.countDraw(scratch.Texture)
+}
+
+// strokeOverlaps checks if drawing over the same pixel twice
+// with the current colour or paint would look any different
+// from drawing over it once.
+func (r *Renderer) strokeOverlaps() bool {
+	switch r.blend {
+	case BlendNone, BlendModulate:
+		return false
+	case BlendAdd, BlendMultiply:
+		return true
+	}
+	if r.alpha != 255 || (r.tint != nil && r.tint.A != 255) {
+		return true
+	}
+	if r.paint != nil {
+		return !paintOpaque(r.paint)
+	}
+	return r.color.A != 255
+}
+
+// scratchCanvas returns a canvas that is at least the given
+// size for drawing into, it is nil if a canvas can't be made.
+func (r *Renderer) scratchCanvas(w, h int) *Canvas {
+	if r.scratch != nil && r.scratch.Width >= w && r.scratch.Height >= h {
+		return r.scratch
+	}
+	if r.scratch != nil {
+		w, h = maxInt(w, r.scratch.Width), maxInt(h, r.scratch.Height)
+		r.scratch.Destroy()
+		r.scratch = nil
+	}
+	canvas, err := NewCanvas(w, h)
+	if err != nil {
+		return nil
+	}
+	r.scratch = canvas
+	return canvas
+}
+
+func (s Stroke) halfWidth() float64 {
+	return math.Max(s.Width, 1) / 2
+}
+
+func (s Stroke) miterLimit() float64 {
+	if s.MiterLimit <= 0 {
+		return 4
+	}
+	return s.MiterLimit
+}
+
+func (p Point) add(o Point) Point        { return Point{p.X + o.X, p.Y + o.Y} }
+func (p Point) sub(o Point) Point        { return Point{p.X - o.X, p.Y - o.Y} }
+func (p Point) mul(s float64) Point      { return Point{p.X * s, p.Y * s} }
+func (p Point) dot(o Point) float64      { return p.X*o.X + p.Y*o.Y }
+func (p Point) length() float64          { return math.Hypot(p.X, p.Y) }
+func (p Point) perpendicular() Point     { return Point{-p.Y, p.X} }
+func (p Point) distance(o Point) float64 { return o.sub(p).length() }
+
+func (p Point) normalize() Point {
+	l := p.length()
+	if l == 0 {
+		return Point{}
+	}
+	return Point{p.X / l, p.Y / l}
+}
+
+// arcSegments returns how many segments are needed to draw an
+// arc of the given radius and angle (in radians) so that it is
+// accurate to within a quarter of a pixel.
+func arcSegments(radius, angle float64) int {
+	if radius <= 0.25 {
+		return 1
+	}
+	step := 2 * math.Acos(1-0.25/radius)
+	return int(math.Max(1, math.Ceil(math.Abs(angle)/step)))
+}
+
+// fan will build triangles for the arc around centre c from
+// the direction a through to the direction b, which are both
+// unit vectors. The arc is drawn through the smaller angle.
+func fan(c, a, b Point, radius float64) []Point {
+	start := math.Atan2(a.Y, a.X)
+	sweep := math.Atan2(b.Y, b.X) - start
+	for sweep > math.Pi {
+		sweep -= 2 * math.Pi
+	}
+	for sweep < -math.Pi {
+		sweep += 2 * math.Pi
+	}
+
+	n := arcSegments(radius, sweep)
+	tris := make([]Point, 0, n*3)
+	prev := c.add(a.mul(radius))
+	for i := 1; i <= n; i++ {
+		t := start + sweep*float64(i)/float64(n)
+		next := Point{c.X + math.Cos(t)*radius, c.Y + math.Sin(t)*radius}
+		tris = append(tris, c, prev, next)
+		prev = next
+	}
+	return tris
+}
+
+// strokeTriangles will turn the line through the given points
+// into a list of triangles, if closed is set the last point joins
+// back onto the first and no caps are drawn.
+func strokeTriangles(points []Point, stroke Stroke, closed bool) []Point {
+	// drop any repeated points as they have no direction.
+	pts := make([]Point, 0, len(points))
+	for _, p := range points {
+		if len(pts) == 0 || pts[len(pts)-1] != p {
+			pts = append(pts, p)
+		}
+	}
+	if closed && len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+		pts = pts[:len(pts)-1]
+	}
+
+	hw := stroke.halfWidth()
+	if len(pts) == 1 {
+		// a single point is only visible if it has
+		// a cap that extends past it.
+		p := pts[0]
+		switch stroke.Cap {
+		case RoundCap:
+			return append(roundCap(p, Point{1, 0}, hw), roundCap(p, Point{-1, 0}, hw)...)
+		case SquareCap:
+			return []Point{
+				{p.X - hw, p.Y - hw}, {p.X + hw, p.Y - hw}, {p.X + hw, p.Y + hw},
+				{p.X - hw, p.Y - hw}, {p.X + hw, p.Y + hw}, {p.X - hw, p.Y + hw},
+			}
+		}
+		return nil
+	}
+	if len(pts) == 0 {
+		return nil
+	}
+	if closed && len(pts) < 3 {
+		closed = false
+	}
+
+	segments := len(pts) - 1
+	if closed {
+		segments = len(pts)
+	}
+
+	var tris []Point
+	for i := 0; i < segments; i++ {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		dir := b.sub(a).normalize()
+		n := dir.perpendicular().mul(hw)
+
+		if !closed && stroke.Cap == SquareCap {
+			if i == 0 {
+				a = a.sub(dir.mul(hw))
+			}
+			if i == segments-1 {
+				b = b.add(dir.mul(hw))
+			}
+		}
+
+		tris = append(tris,
+			a.add(n), b.add(n), b.sub(n),
+			a.add(n), b.sub(n), a.sub(n),
+		)
+	}
+
+	// the joins between each segment.
+	for i := 0; i < len(pts); i++ {
+		if !closed && (i == 0 || i == len(pts)-1) {
+			continue
+		}
+		prev, p, next := pts[(i+len(pts)-1)%len(pts)], pts[i], pts[(i+1)%len(pts)]
+		tris = append(tris, joinTriangles(prev, p, next, stroke)...)
+	}
+
+	if !closed && stroke.Cap == RoundCap {
+		first := pts[1].sub(pts[0]).normalize()
+		last := pts[len(pts)-1].sub(pts[len(pts)-2]).normalize()
+		tris = append(tris, roundCap(pts[0], first.mul(-1), hw)...)
+		tris = append(tris, roundCap(pts[len(pts)-1], last, hw)...)
+	}
+
+	return tris
+}
+
+// roundCap builds a semi-circle at p facing in the direction dir.
+func roundCap(p, dir Point, radius float64) []Point {
+	n := dir.perpendicular()
+	tris := fan(p, n, dir, radius)
+	return append(tris, fan(p, dir, n.mul(-1), radius)...)
+}
+
+// joinTriangles builds the triangles that fill the gap on the
+// outside of the corner at p between the segments prev->p and p->next.
+func joinTriangles(prev, p, next Point, stroke Stroke) []Point {
+	hw := stroke.halfWidth()
+	d0 := p.sub(prev).normalize()
+	d1 := next.sub(p).normalize()
+
+	turn := cross(prev, p, next)
+	if turn == 0 && d0.dot(d1) > 0 {
+		// straight through, no gap to fill.
+		return nil
+	}
+
+	// the outside of the corner is opposite to the turn.
+	n0, n1 := d0.perpendicular(), d1.perpendicular()
+	if turn > 0 {
+		n0, n1 = n0.mul(-1), n1.mul(-1)
+	}
+	a, b := p.add(n0.mul(hw)), p.add(n1.mul(hw))
+
+	switch stroke.Join {
+	case RoundJoin:
+		return fan(p, n0, n1, hw)
+	case MiterJoin:
+		bisector := n0.add(n1).normalize()
+		cos := bisector.dot(n0)
+		if cos > 0 && 1/cos <= stroke.miterLimit() {
+			tip := p.add(bisector.mul(hw / cos))
+			return []Point{p, a, tip, p, tip, b}
+		}
+	}
+	return []Point{p, a, b}
+}
@@ -0,0 +1,334 @@
+package tilemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixangell/strife"
+)
+
+type jsonProperty struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+type jsonProperties []jsonProperty
+
+func (p jsonProperties) toProperties() Properties {
+	props := Properties{}
+	for _, prop := range p {
+		// strings are unquoted, everything else
+		// is kept as it is written.
+		var s string
+		if err := json.Unmarshal(prop.Value, &s); err == nil {
+			props[prop.Name] = s
+			continue
+		}
+		props[prop.Name] = string(prop.Value)
+	}
+	return props
+}
+
+type jsonTile struct {
+	ID         int            `json:"id"`
+	Type       string         `json:"type"`
+	Class      string         `json:"class"`
+	Image      string         `json:"image"`
+	Properties jsonProperties `json:"properties"`
+}
+
+type jsonTileset struct {
+	FirstGID   int    `json:"firstgid"`
+	Source     string `json:"source"`
+	Name       string `json:"name"`
+	TileWidth  int    `json:"tilewidth"`
+	TileHeight int    `json:"tileheight"`
+	Spacing    int    `json:"spacing"`
+	Margin     int    `json:"margin"`
+	TileCount  int    `json:"tilecount"`
+	Columns    int    `json:"columns"`
+	TileOffset struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	} `json:"tileoffset"`
+	Image      string         `json:"image"`
+	Properties jsonProperties `json:"properties"`
+	Tiles      []jsonTile     `json:"tiles"`
+}
+
+type jsonPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type jsonObject struct {
+	ID         int            `json:"id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Class      string         `json:"class"`
+	X          float64        `json:"x"`
+	Y          float64        `json:"y"`
+	Width      float64        `json:"width"`
+	Height     float64        `json:"height"`
+	Rotation   float64        `json:"rotation"`
+	GID        uint32         `json:"gid"`
+	Visible    *bool          `json:"visible"`
+	Ellipse    bool           `json:"ellipse"`
+	Point      bool           `json:"point"`
+	Polygon    []jsonPoint    `json:"polygon"`
+	Polyline   []jsonPoint    `json:"polyline"`
+	Properties jsonProperties `json:"properties"`
+}
+
+type jsonLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Opacity     *float64        `json:"opacity"`
+	Visible     *bool           `json:"visible"`
+	OffsetX     float64         `json:"offsetx"`
+	OffsetY     float64         `json:"offsety"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Data        json.RawMessage `json:"data"`
+	Chunks      json.RawMessage `json:"chunks"`
+	Objects     []jsonObject    `json:"objects"`
+	Layers      []jsonLayer     `json:"layers"`
+	Properties  jsonProperties  `json:"properties"`
+}
+
+type jsonMap struct {
+	Orientation string         `json:"orientation"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	TileWidth   int            `json:"tilewidth"`
+	TileHeight  int            `json:"tileheight"`
+	Infinite    bool           `json:"infinite"`
+	Properties  jsonProperties `json:"properties"`
+	Tilesets    []jsonTileset  `json:"tilesets"`
+	Layers      []jsonLayer    `json:"layers"`
+}
+
+// LoadJSON will load the Tiled .json map at the given path,
+// along with any external tilesets that it uses, which can
+// be either .json or .tsx files.
+func LoadJSON(path string) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load map '%s'", path)
+	}
+
+	var doc jsonMap
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Failed to parse map '%s': %s", path, err)
+	}
+	if doc.Orientation != "" && doc.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("Only orthogonal maps are supported, '%s' is %s", path, doc.Orientation)
+	}
+	if doc.Infinite {
+		return nil, fmt.Errorf("Infinite maps are not supported")
+	}
+
+	m := &Map{
+		Width:      doc.Width,
+		Height:     doc.Height,
+		TileWidth:  doc.TileWidth,
+		TileHeight: doc.TileHeight,
+		Properties: doc.Properties.toProperties(),
+	}
+
+	dir := filepath.Dir(path)
+	for _, t := range doc.Tilesets {
+		ts, err := loadJSONTileset(dir, t)
+		if err != nil {
+			m.Destroy()
+			return nil, err
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+
+	if err := m.addJSONLayers(doc.Layers, layerGroup{1, true, 0, 0}); err != nil {
+		m.Destroy()
+		return nil, err
+	}
+	return m, nil
+}
+
+// loadJSONTileset loads a tileset from a map, reading
+// the tileset file if the tileset is external.
+func loadJSONTileset(dir string, t jsonTileset) (*Tileset, error) {
+	if t.Source != "" {
+		path := filepath.Join(dir, t.Source)
+		if filepath.IsAbs(t.Source) {
+			path = t.Source
+		}
+
+		// json maps can use tilesets saved as tsx
+		if strings.ToLower(filepath.Ext(path)) == ".tsx" {
+			return loadTMXTileset(dir, tmxTileset{FirstGID: t.FirstGID, Source: t.Source})
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load tileset '%s'", path)
+		}
+
+		firstGID := t.FirstGID
+		t = jsonTileset{}
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("Failed to parse tileset '%s': %s", path, err)
+		}
+		t.FirstGID = firstGID
+		dir = filepath.Dir(path)
+	}
+
+	ts := &Tileset{
+		FirstGID:   t.FirstGID,
+		Name:       t.Name,
+		TileWidth:  t.TileWidth,
+		TileHeight: t.TileHeight,
+		Spacing:    t.Spacing,
+		Margin:     t.Margin,
+		TileCount:  t.TileCount,
+		Columns:    t.Columns,
+		OffsetX:    t.TileOffset.X,
+		OffsetY:    t.TileOffset.Y,
+		Properties: t.Properties.toProperties(),
+		Tiles:      map[int]*Tile{},
+	}
+
+	image, err := loadImage(dir, t.Image)
+	if err != nil {
+		return nil, err
+	}
+	ts.Image = image
+
+	for _, tt := range t.Tiles {
+		tile := &Tile{
+			ID:         tt.ID,
+			Type:       tt.Type,
+			Properties: tt.Properties.toProperties(),
+		}
+		if tile.Type == "" {
+			tile.Type = tt.Class
+		}
+		if tile.Image, err = loadImage(dir, tt.Image); err != nil {
+			ts.destroy()
+			return nil, err
+		}
+		ts.Tiles[tt.ID] = tile
+	}
+	return ts, nil
+}
+
+func (m *Map) addJSONLayers(layers []jsonLayer, group layerGroup) error {
+	for _, l := range layers {
+		g := group
+		if l.Opacity != nil {
+			g.opacity *= *l.Opacity
+		}
+		if l.Visible != nil {
+			g.visible = g.visible && *l.Visible
+		}
+		g.offsetX += l.OffsetX
+		g.offsetY += l.OffsetY
+
+		switch l.Type {
+		case "tilelayer":
+			tiles, err := decodeJSONData(l)
+			if err != nil {
+				return fmt.Errorf("Failed to load layer '%s': %s", l.Name, err)
+			}
+			m.Layers = append(m.Layers, &Layer{
+				Name:       l.Name,
+				Width:      l.Width,
+				Height:     l.Height,
+				Tiles:      tiles,
+				Visible:    g.visible,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Properties: l.Properties.toProperties(),
+			})
+
+		case "objectgroup":
+			m.ObjectGroups = append(m.ObjectGroups, &ObjectGroup{
+				Name:       l.Name,
+				Objects:    jsonObjects(l.Objects),
+				Visible:    g.visible,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Properties: l.Properties.toProperties(),
+			})
+
+		case "group":
+			if err := m.addJSONLayers(l.Layers, g); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSONData reads the tiles of a layer, which are
+// either an array of numbers or a base64 string.
+func decodeJSONData(l jsonLayer) ([]uint32, error) {
+	if len(l.Chunks) > 0 {
+		return nil, fmt.Errorf("Infinite maps are not supported")
+	}
+
+	count := l.Width * l.Height
+	if l.Encoding == "base64" {
+		var data string
+		if err := json.Unmarshal(l.Data, &data); err != nil {
+			return nil, fmt.Errorf("Failed to read layer data: %s", err)
+		}
+		return decodeTiles(data, l.Encoding, l.Compression, count)
+	}
+
+	var tiles []uint32
+	if err := json.Unmarshal(l.Data, &tiles); err != nil {
+		return nil, fmt.Errorf("Failed to read layer data: %s", err)
+	}
+	if len(tiles) != count {
+		return nil, fmt.Errorf("Layer has %d tiles but should have %d", len(tiles), count)
+	}
+	return tiles, nil
+}
+
+func jsonObjects(objects []jsonObject) []*Object {
+	var result []*Object
+	for _, o := range objects {
+		obj := &Object{
+			ID:         o.ID,
+			Name:       o.Name,
+			Type:       o.Type,
+			X:          o.X,
+			Y:          o.Y,
+			Width:      o.Width,
+			Height:     o.Height,
+			Rotation:   o.Rotation,
+			GID:        o.GID,
+			Visible:    o.Visible == nil || *o.Visible,
+			Ellipse:    o.Ellipse,
+			Point:      o.Point,
+			Properties: o.Properties.toProperties(),
+		}
+		if obj.Type == "" {
+			obj.Type = o.Class
+		}
+		for _, p := range o.Polygon {
+			obj.Polygon = append(obj.Polygon, strife.Point{X: p.X, Y: p.Y})
+		}
+		for _, p := range o.Polyline {
+			obj.Polyline = append(obj.Polyline, strife.Point{X: p.X, Y: p.Y})
+		}
+		result = append(result, obj)
+	}
+	return result
+}
@@ -0,0 +1,315 @@
+package tilemap
+
+import (
+	"math"
+
+	"github.com/felixangell/strife"
+)
+
+// ChunkSize is how many tiles across and down are cached
+// together into one off-screen texture when drawing.
+const ChunkSize = 16
+
+// chunkLifetime is how many times a layer can be drawn without
+// a chunk before the chunk is thrown away. Keeping chunks for
+// a few draws means a layer drawn more than once a frame, e.g.
+// for split screen, doesn't keep rebuilding them.
+const chunkLifetime = 30
+
+type chunkKey struct {
+	x, y int
+}
+
+// chunk is a cached part of a layer. If the renderer can't
+// draw into a canvas, or can't draw a canvas premultiplied,
+// then canvas is nil and the tiles are drawn one by one instead.
+type chunk struct {
+	canvas *strife.Canvas
+	used   int
+}
+
+// padding is how far tiles can be drawn outside of their
+// cell, e.g. tiles that are taller than the map's cells.
+type padding struct {
+	left, top, right, bottom int
+}
+
+// Draw will render every visible tile layer of the map, with
+// the top left of the map at the origin of the current transform.
+func (m *Map) Draw(r *strife.Renderer) {
+	for _, layer := range m.Layers {
+		m.DrawLayer(r, layer)
+	}
+}
+
+// DrawLayer will render the given tile layer of the map. Only
+// the parts of the layer that are on screen are drawn, and they
+// are cached so that drawing a layer costs a few draws rather
+// than one per tile, on renderers that can draw canvases
+// premultiplied, see strife.Canvas.
+func (m *Map) DrawLayer(r *strife.Renderer, layer *Layer) {
+	if !layer.Visible || layer.Opacity <= 0 || layer.Width <= 0 || layer.Height <= 0 {
+		return
+	}
+	if m.TileWidth <= 0 || m.TileHeight <= 0 {
+		return
+	}
+
+	opacity := uint8(math.Round(math.Min(layer.Opacity, 1) * 255))
+	pad := m.padding()
+	cw, ch := ChunkSize*m.TileWidth, ChunkSize*m.TileHeight
+	layer.draws++
+
+	x0, y0, x1, y1 := visibleArea(r)
+	x0, x1 = x0-layer.OffsetX, x1-layer.OffsetX
+	y0, y1 = y0-layer.OffsetY, y1-layer.OffsetY
+
+	// the chunks that overlap the visible area,
+	// including the tiles that spill out of them.
+	cx0 := maxInt(int(math.Floor((x0-float64(pad.right))/float64(cw))), 0)
+	cy0 := maxInt(int(math.Floor((y0-float64(pad.bottom))/float64(ch))), 0)
+	cx1 := minInt(int(math.Floor((x1+float64(pad.left))/float64(cw))), (layer.Width-1)/ChunkSize)
+	cy1 := minInt(int(math.Floor((y1+float64(pad.top))/float64(ch))), (layer.Height-1)/ChunkSize)
+
+	for cy := cy0; cy <= cy1; cy++ {
+		for cx := cx0; cx <= cx1; cx++ {
+			c := layer.chunk(m, r, cx, cy, pad)
+			x := layer.OffsetX + float64(cx*cw-pad.left)
+			y := layer.OffsetY + float64(cy*ch-pad.top)
+
+			if c.canvas == nil {
+				alpha := r.GetAlpha()
+				r.SetAlpha(uint8((int(alpha)*int(opacity) + 127) / 255))
+				m.drawTiles(r, layer, cx, cy, x+float64(pad.left), y+float64(pad.top))
+				r.SetAlpha(alpha)
+				continue
+			}
+			c.canvas.SetAlpha(opacity)
+			r.DrawImage(c.canvas.Image, strife.DrawOptions{X: x, Y: y})
+		}
+	}
+
+	layer.evictChunks()
+}
+
+// visibleArea returns the box around the part of the screen that
+// can be drawn to, in the co-ordinates of the current transform.
+func visibleArea(r *strife.Renderer) (float64, float64, float64, float64) {
+	w, h := r.GetSize()
+	area := strife.Rectangle{W: w, H: h}
+	if clip, ok := r.GetClip(); ok {
+		area = clip
+	}
+
+	x0, y0 := math.Inf(1), math.Inf(1)
+	x1, y1 := math.Inf(-1), math.Inf(-1)
+	for _, corner := range []strife.Point{
+		{X: float64(area.X), Y: float64(area.Y)},
+		{X: float64(area.X + area.W), Y: float64(area.Y)},
+		{X: float64(area.X), Y: float64(area.Y + area.H)},
+		{X: float64(area.X + area.W), Y: float64(area.Y + area.H)},
+	} {
+		x, y := r.ToLocal(corner.X, corner.Y)
+		x0, y0 = math.Min(x0, x), math.Min(y0, y)
+		x1, y1 = math.Max(x1, x), math.Max(y1, y)
+	}
+	return x0, y0, x1, y1
+}
+
+// padding works out how far any tile can be drawn
+// outside of its cell.
+func (m *Map) padding() padding {
+	if m.pad != nil {
+		return *m.pad
+	}
+
+	var pad padding
+	grow := func(ts *Tileset, w, h int) {
+		x0, y0 := ts.OffsetX, m.TileHeight-h+ts.OffsetY
+		x1, y1 := x0+w, y0+h
+		pad.left = maxInt(pad.left, -x0)
+		pad.top = maxInt(pad.top, -y0)
+		pad.right = maxInt(pad.right, x1-m.TileWidth)
+		pad.bottom = maxInt(pad.bottom, y1-m.TileHeight)
+	}
+
+	for _, ts := range m.Tilesets {
+		grow(ts, ts.TileWidth, ts.TileHeight)
+		for _, tile := range ts.Tiles {
+			if tile.Image != nil {
+				grow(ts, tile.Image.Width, tile.Image.Height)
+			}
+		}
+	}
+	m.pad = &pad
+	return pad
+}
+
+// chunk returns the cached chunk at cx, cy, drawing
+// it first if it isn't cached yet.
+func (l *Layer) chunk(m *Map, r *strife.Renderer, cx, cy int, pad padding) *chunk {
+	key := chunkKey{cx, cy}
+	if c, ok := l.chunks[key]; ok {
+		c.used = l.draws
+		return c
+	}
+	if l.chunks == nil {
+		l.chunks = map[chunkKey]*chunk{}
+	}
+
+	c := &chunk{used: l.draws}
+	l.chunks[key] = c
+
+	w := ChunkSize*m.TileWidth + pad.left + pad.right
+	h := ChunkSize*m.TileHeight + pad.top + pad.bottom
+	canvas, err := strife.NewCanvas(w, h)
+	if err != nil {
+		return c
+	}
+	// without premultiplying, the translucent edges of
+	// tiles would be darker than when drawn directly.
+	if !canvas.Premultiplied() {
+		canvas.Destroy()
+		return c
+	}
+
+	prev := r.GetTarget()
+	if err := r.SetTarget(canvas); err != nil {
+		canvas.Destroy()
+		return c
+	}
+
+	// the tiles are drawn as they are, whatever
+	// the renderer has been set up to do.
+	tint, alpha, blend := r.GetTint(), r.GetAlpha(), r.GetBlendMode()
+	r.SetTint(nil)
+	r.SetAlpha(255)
+	r.SetBlendMode(strife.BlendAlpha)
+	r.Push()
+	r.ResetTransform()
+
+	m.drawTiles(r, l, cx, cy, float64(pad.left), float64(pad.top))
+
+	r.Pop()
+	r.SetBlendMode(blend)
+	r.SetAlpha(alpha)
+	r.SetTint(tint)
+	if prev != nil {
+		r.SetTarget(prev)
+	} else {
+		r.ResetTarget()
+	}
+
+	c.canvas = canvas
+	return c
+}
+
+// drawTiles draws the tiles of the chunk at cx, cy with the
+// top left of the chunk at x, y.
+func (m *Map) drawTiles(r *strife.Renderer, l *Layer, cx, cy int, x, y float64) {
+	for ty := 0; ty < ChunkSize; ty++ {
+		for tx := 0; tx < ChunkSize; tx++ {
+			col, row := cx*ChunkSize+tx, cy*ChunkSize+ty
+			gid := l.Tile(col, row)
+			if gid == 0 {
+				continue
+			}
+
+			ts, ok := m.Tileset(gid)
+			if !ok {
+				continue
+			}
+			image, src, ok := ts.tileImage(int(gid&GIDMask) - ts.FirstGID)
+			if !ok {
+				continue
+			}
+
+			// tiles sit on the bottom left of their cell
+			px := x + float64(tx*m.TileWidth+ts.OffsetX)
+			py := y + float64((ty+1)*m.TileHeight-src.H+ts.OffsetY)
+			drawTile(r, image, src, px, py, gid)
+		}
+	}
+}
+
+// drawTile draws a tile at x, y flipped by the flags in its gid
+func drawTile(r *strife.Renderer, image *strife.Image, src strife.Rectangle, x, y float64, gid uint32) {
+	if gid&^GIDMask == 0 && x == math.Trunc(x) && y == math.Trunc(y) {
+		r.SubImage(image, int(x), int(y), src.X, src.Y, src.W, src.H)
+		return
+	}
+
+	f := strife.Identity()
+	if gid&FlipDiagonal != 0 {
+		f = strife.Matrix{B: 1, C: 1}
+	}
+	if gid&FlipHorizontal != 0 {
+		f = strife.Scaling(-1, 1).Multiply(f)
+	}
+	if gid&FlipVertical != 0 {
+		f = strife.Scaling(1, -1).Multiply(f)
+	}
+
+	// flip around the centre of the tile
+	w, h := float64(src.W), float64(src.H)
+	m := r.GetTransform().
+		Multiply(strife.Translation(x+w/2, y+h/2)).
+		Multiply(f).
+		Multiply(strife.Translation(-w/2, -h/2))
+
+	r.Push()
+	r.SetTransform(m)
+	r.SubImage(image, 0, 0, src.X, src.Y, src.W, src.H)
+	r.Pop()
+}
+
+// invalidate throws away the cached chunk with
+// the given cell in it.
+func (l *Layer) invalidate(x, y int) {
+	key := chunkKey{x / ChunkSize, y / ChunkSize}
+	if c, ok := l.chunks[key]; ok {
+		if c.canvas != nil {
+			c.canvas.Destroy()
+		}
+		delete(l.chunks, key)
+	}
+}
+
+// evictChunks throws away the chunks that haven't
+// been drawn for a while, so scrolling around a big
+// map doesn't keep using up more textures.
+func (l *Layer) evictChunks() {
+	for key, c := range l.chunks {
+		if l.draws-c.used <= chunkLifetime {
+			continue
+		}
+		if c.canvas != nil {
+			c.canvas.Destroy()
+		}
+		delete(l.chunks, key)
+	}
+}
+
+// clearChunks throws away every cached chunk of the layer
+func (l *Layer) clearChunks() {
+	for _, c := range l.chunks {
+		if c.canvas != nil {
+			c.canvas.Destroy()
+		}
+	}
+	l.chunks = nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,341 @@
+// Package tilemap loads maps made with the Tiled map editor,
+// from either its .tmx or .json formats, and renders them
+// with a strife.Renderer.
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/felixangell/strife"
+)
+
+// The flags that Tiled stores in the top bits of a tile's
+// global ID to say how the tile is flipped. The flags are
+// applied diagonal first, then horizontal, then vertical.
+const (
+	FlipHorizontal uint32 = 0x80000000
+	FlipVertical   uint32 = 0x40000000
+	FlipDiagonal   uint32 = 0x20000000
+
+	// GIDMask removes the flip flags from a global ID
+	GIDMask = ^(FlipHorizontal | FlipVertical | FlipDiagonal | 0x10000000)
+)
+
+// Properties are the custom properties of a map, layer,
+// tileset, tile or object. The values are kept as text.
+type Properties map[string]string
+
+// Bool returns the property with the given name as a bool
+func (p Properties) Bool(name string) bool {
+	v, _ := strconv.ParseBool(p[name])
+	return v
+}
+
+// Int returns the property with the given name as an int
+func (p Properties) Int(name string) int {
+	v, _ := strconv.Atoi(p[name])
+	return v
+}
+
+// Float returns the property with the given name as a float
+func (p Properties) Float(name string) float64 {
+	v, _ := strconv.ParseFloat(p[name], 64)
+	return v
+}
+
+// Map is a map loaded from Tiled. Tile layers and object layers
+// are kept in the order they are in the map, with any groups
+// flattened out.
+// Width, Height => The size of the map in tiles; and
+// TileWidth, TileHeight => The size of each cell of the map in pixels.
+type Map struct {
+	Width, Height         int
+	TileWidth, TileHeight int
+	Properties            Properties
+
+	Tilesets     []*Tileset
+	Layers       []*Layer
+	ObjectGroups []*ObjectGroup
+
+	// pad is worked out the first time the map is drawn
+	pad *padding
+}
+
+// Tileset is a set of tiles, either cut from a single image or
+// made up of a collection of images. Tiles in the map refer to
+// it by global IDs starting from FirstGID.
+type Tileset struct {
+	FirstGID              int
+	Name                  string
+	TileWidth, TileHeight int
+	Spacing, Margin       int
+	TileCount, Columns    int
+
+	// OffsetX, OffsetY is where the tiles are drawn
+	// relative to their cell in the map.
+	OffsetX, OffsetY int
+
+	Image      *strife.Image
+	Properties Properties
+
+	// Tiles is the extra information for tiles,
+	// by their ID within the tileset.
+	Tiles map[int]*Tile
+}
+
+// Tile is the extra information a tileset has for
+// one of its tiles. Image is only set for tilesets
+// that are a collection of images.
+type Tile struct {
+	ID         int
+	Type       string
+	Properties Properties
+	Image      *strife.Image
+}
+
+// Layer is a layer of tiles. Tiles holds the global ID of each
+// cell in rows, including the flip flags, where 0 is empty.
+type Layer struct {
+	Name             string
+	Width, Height    int
+	Tiles            []uint32
+	Visible          bool
+	Opacity          float64
+	OffsetX, OffsetY float64
+	Properties       Properties
+
+	chunks map[chunkKey]*chunk
+	draws  int
+}
+
+// ObjectGroup is a layer of objects
+type ObjectGroup struct {
+	Name             string
+	Objects          []*Object
+	Visible          bool
+	Opacity          float64
+	OffsetX, OffsetY float64
+	Properties       Properties
+}
+
+// Object is a shape or tile placed in an object layer. Points
+// of polygons and polylines are relative to X, Y. If GID is set
+// the object is a tile, and X, Y is its bottom left corner.
+type Object struct {
+	ID                  int
+	Name, Type          string
+	X, Y, Width, Height float64
+	Rotation            float64
+	GID                 uint32
+	Visible             bool
+	Ellipse, Point      bool
+	Polygon, Polyline   []strife.Point
+	Properties          Properties
+}
+
+// Load will load the Tiled map at the given path, which can
+// be a .tmx or .json map. The tileset images are loaded too,
+// so the render context must be set up first.
+func Load(path string) (*Map, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tmx":
+		return LoadTMX(path)
+	case ".json", ".tmj":
+		return LoadJSON(path)
+	}
+	return nil, fmt.Errorf("Unknown map format '%s'", path)
+}
+
+// Tile returns the layer's global ID at the given cell,
+// or 0 if the cell is outside of the layer.
+func (l *Layer) Tile(x, y int) uint32 {
+	if x < 0 || y < 0 || x >= l.Width || y >= l.Height {
+		return 0
+	}
+	return l.Tiles[y*l.Width+x]
+}
+
+// SetTile changes the global ID at the given cell.
+func (l *Layer) SetTile(x, y int, gid uint32) {
+	if x < 0 || y < 0 || x >= l.Width || y >= l.Height {
+		return
+	}
+	l.Tiles[y*l.Width+x] = gid
+	l.invalidate(x, y)
+}
+
+// Tileset returns the tileset that the given global ID is from
+func (m *Map) Tileset(gid uint32) (*Tileset, bool) {
+	id := int(gid & GIDMask)
+	if id == 0 {
+		return nil, false
+	}
+	for i := len(m.Tilesets) - 1; i >= 0; i-- {
+		if ts := m.Tilesets[i]; id >= ts.FirstGID {
+			return ts, true
+		}
+	}
+	return nil, false
+}
+
+// TileImage returns the image and the area of it to draw
+// for the given global ID.
+func (m *Map) TileImage(gid uint32) (*strife.Image, strife.Rectangle, bool) {
+	ts, ok := m.Tileset(gid)
+	if !ok {
+		return nil, strife.Rectangle{}, false
+	}
+	return ts.tileImage(int(gid&GIDMask) - ts.FirstGID)
+}
+
+// TileProperties returns the properties of the tile
+// with the given global ID, which can be nil.
+func (m *Map) TileProperties(gid uint32) Properties {
+	ts, ok := m.Tileset(gid)
+	if !ok {
+		return nil
+	}
+	if tile, ok := ts.Tiles[int(gid&GIDMask)-ts.FirstGID]; ok {
+		return tile.Properties
+	}
+	return nil
+}
+
+func (ts *Tileset) tileImage(id int) (*strife.Image, strife.Rectangle, bool) {
+	if tile, ok := ts.Tiles[id]; ok && tile.Image != nil {
+		return tile.Image, strife.Rectangle{W: tile.Image.Width, H: tile.Image.Height}, true
+	}
+	if ts.Image == nil || id < 0 || (ts.TileCount > 0 && id >= ts.TileCount) {
+		return nil, strife.Rectangle{}, false
+	}
+
+	columns := ts.Columns
+	if columns <= 0 {
+		columns = (ts.Image.Width - ts.Margin*2 + ts.Spacing) / (ts.TileWidth + ts.Spacing)
+	}
+	if columns <= 0 {
+		return nil, strife.Rectangle{}, false
+	}
+	return ts.Image, strife.Rectangle{
+		X: ts.Margin + (id%columns)*(ts.TileWidth+ts.Spacing),
+		Y: ts.Margin + (id/columns)*(ts.TileHeight+ts.Spacing),
+		W: ts.TileWidth,
+		H: ts.TileHeight,
+	}, true
+}
+
+// Destroy must be invoked when finished with the map, it
+// frees the tileset images and any cached chunks.
+func (m *Map) Destroy() {
+	for _, layer := range m.Layers {
+		layer.clearChunks()
+	}
+	for _, ts := range m.Tilesets {
+		ts.destroy()
+	}
+}
+
+func (ts *Tileset) destroy() {
+	if ts.Image != nil {
+		ts.Image.Destroy()
+	}
+	for _, tile := range ts.Tiles {
+		if tile.Image != nil {
+			tile.Image.Destroy()
+		}
+	}
+}
+
+// decodeTiles decodes the tile data of a layer, which is either
+// comma separated or base64 and possibly compressed.
+func decodeTiles(data, encoding, compression string, count int) ([]uint32, error) {
+	var tiles []uint32
+	switch encoding {
+	case "csv":
+		for _, field := range strings.Split(data, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("Bad tile '%s' in layer data", field)
+			}
+			tiles = append(tiles, uint32(gid))
+		}
+
+	case "base64":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode layer data: %s", err)
+		}
+
+		var r io.Reader = bytes.NewReader(raw)
+		switch compression {
+		case "":
+		case "zlib":
+			if r, err = zlib.NewReader(r); err != nil {
+				return nil, fmt.Errorf("Failed to decompress layer data: %s", err)
+			}
+		case "gzip":
+			if r, err = gzip.NewReader(r); err != nil {
+				return nil, fmt.Errorf("Failed to decompress layer data: %s", err)
+			}
+		default:
+			return nil, fmt.Errorf("Unsupported layer compression '%s'", compression)
+		}
+
+		tiles = make([]uint32, count)
+		if err := binary.Read(r, binary.LittleEndian, tiles); err != nil {
+			return nil, fmt.Errorf("Failed to read layer data: %s", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("Unsupported layer encoding '%s'", encoding)
+	}
+
+	if len(tiles) != count {
+		return nil, fmt.Errorf("Layer has %d tiles but should have %d", len(tiles), count)
+	}
+	return tiles, nil
+}
+
+// parsePoints parses the points of a TMX polygon,
+// e.g. "0,0 10,5 3,8".
+func parsePoints(s string) ([]strife.Point, error) {
+	var points []strife.Point
+	for _, pair := range strings.Fields(s) {
+		xy := strings.Split(pair, ",")
+		if len(xy) != 2 {
+			return nil, fmt.Errorf("Bad point '%s'", pair)
+		}
+		x, errX := strconv.ParseFloat(xy[0], 64)
+		y, errY := strconv.ParseFloat(xy[1], 64)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("Bad point '%s'", pair)
+		}
+		points = append(points, strife.Point{X: x, Y: y})
+	}
+	return points, nil
+}
+
+// loadImage loads an image that is referenced by a map or
+// tileset, relative to the directory of that file.
+func loadImage(dir, source string) (*strife.Image, error) {
+	if source == "" {
+		return nil, nil
+	}
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(dir, source)
+	}
+	return strife.LoadImage(source)
+}
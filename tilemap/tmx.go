@@ -0,0 +1,357 @@
+package tilemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type tmxProperties struct {
+	Properties []tmxProperty `xml:"property"`
+}
+
+func (p tmxProperties) toProperties() Properties {
+	props := Properties{}
+	for _, prop := range p.Properties {
+		// multi-line values are stored as text
+		// rather than in the value attribute.
+		value := prop.Value
+		if value == "" {
+			value = prop.Text
+		}
+		props[prop.Name] = value
+	}
+	return props
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+}
+
+type tmxTile struct {
+	ID         int           `xml:"id,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	Properties tmxProperties `xml:"properties"`
+	Image      tmxImage      `xml:"image"`
+}
+
+type tmxTileset struct {
+	FirstGID   int    `xml:"firstgid,attr"`
+	Source     string `xml:"source,attr"`
+	Name       string `xml:"name,attr"`
+	TileWidth  int    `xml:"tilewidth,attr"`
+	TileHeight int    `xml:"tileheight,attr"`
+	Spacing    int    `xml:"spacing,attr"`
+	Margin     int    `xml:"margin,attr"`
+	TileCount  int    `xml:"tilecount,attr"`
+	Columns    int    `xml:"columns,attr"`
+	TileOffset struct {
+		X int `xml:"x,attr"`
+		Y int `xml:"y,attr"`
+	} `xml:"tileoffset"`
+	Image      tmxImage      `xml:"image"`
+	Properties tmxProperties `xml:"properties"`
+	Tiles      []tmxTile     `xml:"tile"`
+}
+
+type tmxData struct {
+	Encoding    string `xml:"encoding,attr"`
+	Compression string `xml:"compression,attr"`
+	Text        string `xml:",chardata"`
+	Tiles       []struct {
+		GID uint32 `xml:"gid,attr"`
+	} `xml:"tile"`
+	Chunks []struct{} `xml:"chunk"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	Rotation   float64       `xml:"rotation,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Visible    string        `xml:"visible,attr"`
+	Properties tmxProperties `xml:"properties"`
+	Ellipse    *struct{}     `xml:"ellipse"`
+	Point      *struct{}     `xml:"point"`
+	Polygon    *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polygon"`
+	Polyline *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polyline"`
+}
+
+// tmxLayer is a layer, object group or group, the
+// XMLName says which one. Children are the layers
+// inside of a group.
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string        `xml:"name,attr"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Opacity    string        `xml:"opacity,attr"`
+	Visible    string        `xml:"visible,attr"`
+	OffsetX    float64       `xml:"offsetx,attr"`
+	OffsetY    float64       `xml:"offsety,attr"`
+	Properties tmxProperties `xml:"properties"`
+	Data       tmxData       `xml:"data"`
+	Objects    []tmxObject   `xml:"object"`
+	Children   []tmxLayer    `xml:",any"`
+}
+
+type tmxMap struct {
+	Orientation string        `xml:"orientation,attr"`
+	Width       int           `xml:"width,attr"`
+	Height      int           `xml:"height,attr"`
+	TileWidth   int           `xml:"tilewidth,attr"`
+	TileHeight  int           `xml:"tileheight,attr"`
+	Infinite    int           `xml:"infinite,attr"`
+	Properties  tmxProperties `xml:"properties"`
+	Tilesets    []tmxTileset  `xml:"tileset"`
+	Layers      []tmxLayer    `xml:",any"`
+}
+
+// LoadTMX will load the Tiled .tmx map at the given path,
+// along with any external .tsx tilesets that it uses.
+func LoadTMX(path string) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load map '%s'", path)
+	}
+
+	var doc tmxMap
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Failed to parse map '%s': %s", path, err)
+	}
+	if doc.Orientation != "" && doc.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("Only orthogonal maps are supported, '%s' is %s", path, doc.Orientation)
+	}
+	if doc.Infinite != 0 {
+		return nil, fmt.Errorf("Infinite maps are not supported")
+	}
+
+	m := &Map{
+		Width:      doc.Width,
+		Height:     doc.Height,
+		TileWidth:  doc.TileWidth,
+		TileHeight: doc.TileHeight,
+		Properties: doc.Properties.toProperties(),
+	}
+
+	dir := filepath.Dir(path)
+	for _, t := range doc.Tilesets {
+		ts, err := loadTMXTileset(dir, t)
+		if err != nil {
+			m.Destroy()
+			return nil, err
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+
+	if err := m.addTMXLayers(doc.Layers, layerGroup{1, true, 0, 0}); err != nil {
+		m.Destroy()
+		return nil, err
+	}
+	return m, nil
+}
+
+// loadTMXTileset loads a tileset from a map, reading
+// the .tsx file if the tileset is external.
+func loadTMXTileset(dir string, t tmxTileset) (*Tileset, error) {
+	if t.Source != "" {
+		path := filepath.Join(dir, t.Source)
+		if filepath.IsAbs(t.Source) {
+			path = t.Source
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load tileset '%s'", path)
+		}
+
+		firstGID := t.FirstGID
+		t = tmxTileset{}
+		if err := xml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("Failed to parse tileset '%s': %s", path, err)
+		}
+		t.FirstGID = firstGID
+		dir = filepath.Dir(path)
+	}
+
+	ts := &Tileset{
+		FirstGID:   t.FirstGID,
+		Name:       t.Name,
+		TileWidth:  t.TileWidth,
+		TileHeight: t.TileHeight,
+		Spacing:    t.Spacing,
+		Margin:     t.Margin,
+		TileCount:  t.TileCount,
+		Columns:    t.Columns,
+		OffsetX:    t.TileOffset.X,
+		OffsetY:    t.TileOffset.Y,
+		Properties: t.Properties.toProperties(),
+		Tiles:      map[int]*Tile{},
+	}
+
+	image, err := loadImage(dir, t.Image.Source)
+	if err != nil {
+		return nil, err
+	}
+	ts.Image = image
+
+	for _, tt := range t.Tiles {
+		tile := &Tile{
+			ID:         tt.ID,
+			Type:       tt.Type,
+			Properties: tt.Properties.toProperties(),
+		}
+		if tile.Type == "" {
+			tile.Type = tt.Class
+		}
+		if tile.Image, err = loadImage(dir, tt.Image.Source); err != nil {
+			ts.destroy()
+			return nil, err
+		}
+		ts.Tiles[tt.ID] = tile
+	}
+	return ts, nil
+}
+
+// layerGroup is what the groups that a layer is
+// inside of add to the layer.
+type layerGroup struct {
+	opacity          float64
+	visible          bool
+	offsetX, offsetY float64
+}
+
+func (g layerGroup) apply(l tmxLayer) layerGroup {
+	opacity := 1.0
+	if l.Opacity != "" {
+		opacity, _ = strconv.ParseFloat(l.Opacity, 64)
+	}
+	return layerGroup{
+		opacity: g.opacity * opacity,
+		visible: g.visible && l.Visible != "0",
+		offsetX: g.offsetX + l.OffsetX,
+		offsetY: g.offsetY + l.OffsetY,
+	}
+}
+
+func (m *Map) addTMXLayers(layers []tmxLayer, group layerGroup) error {
+	for _, l := range layers {
+		g := group.apply(l)
+
+		switch l.XMLName.Local {
+		case "layer":
+			tiles, err := decodeTMXData(l.Data, l.Width*l.Height)
+			if err != nil {
+				return fmt.Errorf("Failed to load layer '%s': %s", l.Name, err)
+			}
+			m.Layers = append(m.Layers, &Layer{
+				Name:       l.Name,
+				Width:      l.Width,
+				Height:     l.Height,
+				Tiles:      tiles,
+				Visible:    g.visible,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Properties: l.Properties.toProperties(),
+			})
+
+		case "objectgroup":
+			objects, err := tmxObjects(l.Objects)
+			if err != nil {
+				return fmt.Errorf("Failed to load object layer '%s': %s", l.Name, err)
+			}
+			m.ObjectGroups = append(m.ObjectGroups, &ObjectGroup{
+				Name:       l.Name,
+				Objects:    objects,
+				Visible:    g.visible,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Properties: l.Properties.toProperties(),
+			})
+
+		case "group":
+			if err := m.addTMXLayers(l.Children, g); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeTMXData(data tmxData, count int) ([]uint32, error) {
+	if len(data.Chunks) > 0 {
+		return nil, fmt.Errorf("Infinite maps are not supported")
+	}
+
+	// without an encoding each tile is its own element
+	if data.Encoding == "" {
+		tiles := make([]uint32, 0, count)
+		for _, t := range data.Tiles {
+			tiles = append(tiles, t.GID)
+		}
+		if len(tiles) != count {
+			return nil, fmt.Errorf("Layer has %d tiles but should have %d", len(tiles), count)
+		}
+		return tiles, nil
+	}
+	return decodeTiles(data.Text, data.Encoding, data.Compression, count)
+}
+
+func tmxObjects(objects []tmxObject) ([]*Object, error) {
+	var result []*Object
+	for _, o := range objects {
+		obj := &Object{
+			ID:         o.ID,
+			Name:       o.Name,
+			Type:       o.Type,
+			X:          o.X,
+			Y:          o.Y,
+			Width:      o.Width,
+			Height:     o.Height,
+			Rotation:   o.Rotation,
+			GID:        o.GID,
+			Visible:    strings.TrimSpace(o.Visible) != "0",
+			Ellipse:    o.Ellipse != nil,
+			Point:      o.Point != nil,
+			Properties: o.Properties.toProperties(),
+		}
+		if obj.Type == "" {
+			obj.Type = o.Class
+		}
+
+		var err error
+		if o.Polygon != nil {
+			if obj.Polygon, err = parsePoints(o.Polygon.Points); err != nil {
+				return nil, err
+			}
+		}
+		if o.Polyline != nil {
+			if obj.Polyline, err = parsePoints(o.Polyline.Points); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package strife
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Matrix is a 2D affine transformation. A point x, y
+// is transformed to:
+//
+//	x' = A*x + C*y + E
+//	y' = B*x + D*y + F
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the matrix that leaves
+// points where they are.
+func Identity() Matrix {
+	return Matrix{A: 1, D: 1}
+}
+
+// Translation returns a matrix that moves points by x, y
+func Translation(x, y float64) Matrix {
+	return Matrix{A: 1, D: 1, E: x, F: y}
+}
+
+// Scaling returns a matrix that scales points by sx, sy
+func Scaling(sx, sy float64) Matrix {
+	return Matrix{A: sx, D: sy}
+}
+
+// Rotation returns a matrix that rotates points clockwise
+// on screen by the given angle in degrees.
+func Rotation(degrees float64) Matrix {
+	sin, cos := math.Sincos(degrees * math.Pi / 180)
+	return Matrix{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Multiply returns the matrix that applies o first
+// and then this matrix.
+func (m Matrix) Multiply(o Matrix) Matrix {
+	return Matrix{
+		A: m.A*o.A + m.C*o.B,
+		B: m.B*o.A + m.D*o.B,
+		C: m.A*o.C + m.C*o.D,
+		D: m.B*o.C + m.D*o.D,
+		E: m.A*o.E + m.C*o.F + m.E,
+		F: m.B*o.E + m.D*o.F + m.F,
+	}
+}
+
+// Apply will transform the point x, y by this matrix
+func (m Matrix) Apply(x, y float64) (float64, float64) {
+	return m.A*x + m.C*y + m.E, m.B*x + m.D*y + m.F
+}
+
+// Invert returns the inverse of this matrix, if the matrix
+// can't be inverted then false is returned.
+func (m Matrix) Invert() (Matrix, bool) {
+	det := m.A*m.D - m.B*m.C
+	if det == 0 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// IsTranslation checks if the matrix only moves
+// points, i.e. there is no scale or rotation.
+func (m Matrix) IsTranslation() bool {
+	return m.A == 1 && m.B == 0 && m.C == 0 && m.D == 1
+}
+
+// scale returns the largest amount that the matrix
+// will stretch a distance by.
+func (m Matrix) scale() float64 {
+	return math.Max(math.Hypot(m.A, m.B), math.Hypot(m.C, m.D))
+}
+
+// Push saves the current transform so it can be
+// restored with Pop.
+func (r *Renderer) Push() {
+	r.transforms = append(r.transforms, r.transform)
+}
+
+// Pop restores the transform that was last saved
+// with Push.
+func (r *Renderer) Pop() {
+	if len(r.transforms) == 0 {
+		panic("Pop called without a matching Push")
+	}
+	r.transform = r.transforms[len(r.transforms)-1]
+	r.transforms = r.transforms[:len(r.transforms)-1]
+}
+
+// Translate moves everything that is drawn afterwards by x, y
+func (r *Renderer) Translate(x, y float64) {
+	r.transform = r.transform.Multiply(Translation(x, y))
+}
+
+// Scale scales everything that is drawn afterwards by sx, sy
+func (r *Renderer) Scale(sx, sy float64) {
+	r.transform = r.transform.Multiply(Scaling(sx, sy))
+}
+
+// Rotate rotates everything that is drawn afterwards clockwise
+// by the given angle in degrees around the current origin.
+func (r *Renderer) Rotate(degrees float64) {
+	r.transform = r.transform.Multiply(Rotation(degrees))
+}
+
+// GetTransform returns the current transform
+func (r *Renderer) GetTransform() Matrix {
+	return r.transform
+}
+
+// SetTransform replaces the current transform
+func (r *Renderer) SetTransform(m Matrix) {
+	r.transform = m
+}
+
+// ResetTransform sets the current transform back
+// to the identity.
+func (r *Renderer) ResetTransform() {
+	r.transform = Identity()
+}
+
+// ToScreen maps the point x, y from the current
+// transform to screen co-ordinates.
+func (r *Renderer) ToScreen(x, y float64) (float64, float64) {
+	return r.transform.Apply(x, y)
+}
+
+// ToLocal maps the point x, y from screen co-ordinates
+// into the current transform, e.g. to find where the mouse
+// is in a scrolled or scaled view.
+func (r *Renderer) ToLocal(x, y float64) (float64, float64) {
+	inv, ok := r.transform.Invert()
+	if !ok {
+		return x, y
+	}
+	return inv.Apply(x, y)
+}
+
+// offset returns the translation of the current transform
+// rounded to the nearest pixel, and if the transform is only
+// a translation. This is used to keep pixel exact drawing
+// when there is no scale or rotation.
+func (r *Renderer) offset() (int32, int32, bool) {
+	m := r.transform
+	return int32(math.Round(m.E)), int32(math.Round(m.F)), m.IsTranslation()
+}
+
+// copy will draw the source rectangle of the given texture
+// to the rectangle x, y, w, h with the current transform applied.
+func (r *Renderer) copy(texture *sdl.Texture, src *sdl.Rect, x, y, w, h float64) {
+	r.copyTransformed(texture, src, r.transform.Multiply(Translation(x, y)), w, h)
+}
+
+// copyTransformed will draw the source rectangle of the given
+// texture as a w by h rectangle at the origin transformed by m.
+// The matrix may rotate, scale or mirror the texture, but it
+// can't skew it.
+func (r *Renderer) copyTransformed(texture *sdl.Texture, src *sdl.Rect, m Matrix, w, h float64) {
+	if r.clippedAway() {
+		return
+	}
+	r.countDraw(texture)
+	if m.IsTranslation() {
+		x, y := m.E, m.F
+		if x == math.Trunc(x) && y == math.Trunc(y) && w == math.Trunc(w) && h == math.Trunc(h) {
+			r.Copy(texture, src, &sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		} else {
+			r.CopyF(texture, src, &sdl.FRect{float32(x), float32(y), float32(w), float32(h)})
+		}
+		return
+	}
+
+	// split the matrix into a rotation and scale, if the
+	// matrix mirrors then the y axis is flipped.
+	sx := math.Hypot(m.A, m.B)
+	det := m.A*m.D - m.B*m.C
+	if sx == 0 || det == 0 {
+		return
+	}
+	sy := det / sx
+	angle := math.Atan2(m.B, m.A) * 180 / math.Pi
+
+	dw, dh := w*sx, h*math.Abs(sy)
+
+	flip := sdl.FLIP_NONE
+	dst := &sdl.FRect{float32(m.E), float32(m.F), float32(dw), float32(dh)}
+	centre := &sdl.FPoint{}
+	if sy < 0 {
+		flip = sdl.FLIP_VERTICAL
+		dst.Y -= float32(dh)
+		centre.Y = float32(dh)
+	}
+	r.CopyExF(texture, src, dst, angle, centre, flip)
+}
@@ -79,9 +79,10 @@ func (w *RenderWindow) handleMouseButtonEvent(evt *sdl.MouseButtonEvent) {
 }
 
 func (w *RenderWindow) handleMouseMotionEvent(evt *sdl.MouseMotionEvent) {
-	w.handler(&MouseMoveEvent{BaseEvent{}, int(evt.X), int(evt.Y)})
-	mouseInstance.X = int(evt.X)
-	mouseInstance.Y = int(evt.Y)
+	x, y := w.ToLogical(int(evt.X), int(evt.Y))
+	w.handler(&MouseMoveEvent{BaseEvent{}, x, y})
+	mouseInstance.X = x
+	mouseInstance.Y = y
 
 	switch evt.State {
 	case sdl.BUTTON_LEFT:
@@ -164,6 +165,10 @@ func (w *RenderWindow) GetRenderContext() *Renderer {
 // and destroy the context.
 func (w *RenderWindow) Close() {
 	w.closeRequested = true
+	if scratch := w.renderContext.scratch; scratch != nil {
+		scratch.Destroy()
+		w.renderContext.scratch = nil
+	}
 	w.renderContext.Destroy()
 	w.Destroy()
 }
@@ -183,6 +188,8 @@ func (w *RenderWindow) AllowHighDPI() {
 // Create window take all of the settings and create
 // a window with a rendering context
 func (w *RenderWindow) Create() error {
+	// headless windows aren't hidden, SDL skips drawing to hidden
+	// windows and the headless drivers never show them anyway.
 	windowHandle, err := sdl.CreateWindow("", sdl.WINDOWPOS_CENTERED, sdl.WINDOWPOS_CENTERED, int32(w.w), int32(w.h), w.flags)
 	if err != nil {
 		return fmt.Errorf("Failed to create window")